@@ -0,0 +1,303 @@
+package bitstream
+
+import "math/bits"
+
+// The bitwise operations treat Bits and BitVectors as unsigned numbers, with index 0 as the
+// least-significant bit, and return new values rather than modifying their operands.
+// When the lengths of the operands of And, Or and Xor differ, the shorter one is extended
+// with zero bits, so the result has the length of the longer one. Shifts and rotations keep
+// the length, as they would for a fixed-width integer.
+
+// And returns the bitwise AND of b and other
+func (b Bits) And(other Bits) Bits {
+	return b.combine(other, func(x, y bool) bool { return x && y })
+}
+
+// Or returns the bitwise OR of b and other
+func (b Bits) Or(other Bits) Bits {
+	return b.combine(other, func(x, y bool) bool { return x || y })
+}
+
+// Xor returns the bitwise XOR of b and other
+func (b Bits) Xor(other Bits) Bits {
+	return b.combine(other, func(x, y bool) bool { return x != y })
+}
+
+// combine applies op to each pair of bits, extending the shorter operand with zero bits
+func (b Bits) combine(other Bits, op func(x, y bool) bool) Bits {
+	result := make(Bits, len(b))
+	if len(other) > len(b) {
+		result = make(Bits, len(other))
+	}
+
+	for idx := range result {
+		result[idx] = op(idx < len(b) && b[idx], idx < len(other) && other[idx])
+	}
+
+	return result
+}
+
+// Not returns the bitwise complement of b
+func (b Bits) Not() Bits {
+	result := make(Bits, len(b))
+
+	for idx := range b {
+		result[idx] = !b[idx]
+	}
+
+	return result
+}
+
+// ShiftLeft moves every bit n places toward the most-significant end, like the << operator.
+// The bits shifted past the end are lost, and zero bits are shifted in. A negative n shifts right.
+func (b Bits) ShiftLeft(n int) Bits {
+	result := make(Bits, len(b))
+
+	for idx := range result {
+		if from := idx - n; from >= 0 && from < len(b) {
+			result[idx] = b[from]
+		}
+	}
+
+	return result
+}
+
+// ShiftRight moves every bit n places toward the least-significant end, like the >> operator
+// for an unsigned integer. A negative n shifts left.
+func (b Bits) ShiftRight(n int) Bits {
+	return b.ShiftLeft(-n)
+}
+
+// Rotate moves every bit n places toward the most-significant end, wrapping the bits shifted
+// past the end around to the start, like bits.RotateLeft. A negative n rotates right.
+func (b Bits) Rotate(n int) Bits {
+	result := make(Bits, len(b))
+
+	if len(b) == 0 {
+		return result
+	}
+
+	n %= len(b)
+	if n < 0 {
+		n += len(b)
+	}
+
+	copy(result[n:], b)
+	copy(result, b[len(b)-n:])
+
+	return result
+}
+
+// OnesCount returns the number of one bits
+func (b Bits) OnesCount() int {
+	count := 0
+
+	for idx := range b {
+		if b[idx] {
+			count++
+		}
+	}
+
+	return count
+}
+
+// LeadingZeros returns the number of zero bits at the most-significant end,
+// which is the length of b if every bit is zero
+func (b Bits) LeadingZeros() int {
+	for idx := len(b) - 1; idx >= 0; idx-- {
+		if b[idx] {
+			return len(b) - 1 - idx
+		}
+	}
+
+	return len(b)
+}
+
+// TrailingZeros returns the number of zero bits at the least-significant end,
+// which is the length of b if every bit is zero
+func (b Bits) TrailingZeros() int {
+	for idx := range b {
+		if b[idx] {
+			return idx
+		}
+	}
+
+	return len(b)
+}
+
+// Reverse returns the bits in reverse order
+func (b Bits) Reverse() Bits {
+	result := make(Bits, len(b))
+
+	for idx := range b {
+		result[len(b)-1-idx] = b[idx]
+	}
+
+	return result
+}
+
+// And returns the bitwise AND of v and other, see Bits.And
+func (v BitVector) And(other BitVector) BitVector {
+	return v.combine(other, func(x, y uint64) uint64 { return x & y })
+}
+
+// Or returns the bitwise OR of v and other, see Bits.Or
+func (v BitVector) Or(other BitVector) BitVector {
+	return v.combine(other, func(x, y uint64) uint64 { return x | y })
+}
+
+// Xor returns the bitwise XOR of v and other, see Bits.Xor
+func (v BitVector) Xor(other BitVector) BitVector {
+	return v.combine(other, func(x, y uint64) uint64 { return x ^ y })
+}
+
+// combine applies op to each pair of words, extending the shorter operand with zero words.
+// The bits past the length stay zero, as op yields zero for two zero bits.
+func (v BitVector) combine(other BitVector, op func(x, y uint64) uint64) BitVector {
+	result := NewBitVector(v.length)
+	if other.length > v.length {
+		result = NewBitVector(other.length)
+	}
+
+	for idx := range result.words {
+		x, y := uint64(0), uint64(0)
+
+		if idx < len(v.words) {
+			x = v.words[idx]
+		}
+
+		if idx < len(other.words) {
+			y = other.words[idx]
+		}
+
+		result.words[idx] = op(x, y)
+	}
+
+	return result
+}
+
+// Not returns the bitwise complement of v
+func (v BitVector) Not() BitVector {
+	result := NewBitVector(v.length)
+
+	for idx := range v.words {
+		result.words[idx] = ^v.words[idx]
+	}
+
+	return result.trim()
+}
+
+// ShiftLeft moves every bit n places toward the most-significant end, see Bits.ShiftLeft
+func (v BitVector) ShiftLeft(n int) BitVector {
+	if n < 0 {
+		return v.ShiftRight(-n)
+	}
+
+	result := NewBitVector(v.length)
+	wordShift, bitShift := n/bitsPerWord, uint(n%bitsPerWord)
+
+	for idx := len(result.words) - 1; idx >= wordShift; idx-- {
+		result.words[idx] = v.words[idx-wordShift] << bitShift
+
+		if bitShift > 0 && idx-wordShift-1 >= 0 {
+			result.words[idx] |= v.words[idx-wordShift-1] >> (bitsPerWord - bitShift)
+		}
+	}
+
+	return result.trim()
+}
+
+// ShiftRight moves every bit n places toward the least-significant end, see Bits.ShiftRight
+func (v BitVector) ShiftRight(n int) BitVector {
+	if n < 0 {
+		return v.ShiftLeft(-n)
+	}
+
+	result := NewBitVector(v.length)
+	wordShift, bitShift := n/bitsPerWord, uint(n%bitsPerWord)
+
+	for idx := 0; idx+wordShift < len(v.words); idx++ {
+		result.words[idx] = v.words[idx+wordShift] >> bitShift
+
+		if bitShift > 0 && idx+wordShift+1 < len(v.words) {
+			result.words[idx] |= v.words[idx+wordShift+1] << (bitsPerWord - bitShift)
+		}
+	}
+
+	return result
+}
+
+// Rotate moves every bit n places toward the most-significant end, wrapping around,
+// see Bits.Rotate
+func (v BitVector) Rotate(n int) BitVector {
+	if v.length == 0 {
+		return NewBitVector(0)
+	}
+
+	n %= v.length
+	if n < 0 {
+		n += v.length
+	}
+
+	return v.ShiftLeft(n).Or(v.ShiftRight(v.length - n))
+}
+
+// OnesCount returns the number of one bits
+func (v BitVector) OnesCount() int {
+	count := 0
+
+	for _, word := range v.words {
+		count += bits.OnesCount64(word)
+	}
+
+	return count
+}
+
+// LeadingZeros returns the number of zero bits at the most-significant end,
+// which is the length of v if every bit is zero
+func (v BitVector) LeadingZeros() int {
+	for idx := len(v.words) - 1; idx >= 0; idx-- {
+		if v.words[idx] != 0 {
+			return v.length - (idx*bitsPerWord + bitsPerWord - bits.LeadingZeros64(v.words[idx]))
+		}
+	}
+
+	return v.length
+}
+
+// TrailingZeros returns the number of zero bits at the least-significant end,
+// which is the length of v if every bit is zero
+func (v BitVector) TrailingZeros() int {
+	for idx, word := range v.words {
+		if word != 0 {
+			return idx*bitsPerWord + bits.TrailingZeros64(word)
+		}
+	}
+
+	return v.length
+}
+
+// Reverse returns the bits in reverse order
+func (v BitVector) Reverse() BitVector {
+	// reversing the words, and the bits within each, reverses a whole number of words,
+	// leaving the bits past the length at the least-significant end
+	reversed := NewBitVector(len(v.words) * bitsPerWord)
+
+	for idx, word := range v.words {
+		reversed.words[len(v.words)-1-idx] = bits.Reverse64(word)
+	}
+
+	result := reversed.ShiftRight(reversed.length - v.length)
+	result.length = v.length
+
+	return result
+}
+
+// trim clears the bits past the length, which the word operations may have set
+func (v BitVector) trim() BitVector {
+	if extra := len(v.words)*bitsPerWord - v.length; extra > 0 {
+		v.words[len(v.words)-1] &= ^uint64(0) >> uint(extra)
+	}
+
+	return v
+}
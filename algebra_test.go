@@ -0,0 +1,94 @@
+package bitstream
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBits_Algebra(t *testing.T) {
+	a := Bits{T, T, F, F, T}
+	b := Bits{T, F, T}
+
+	tests := []struct {
+		name string
+		got  interface{}
+		want interface{}
+	}{
+		{"And, lengths differ", a.And(b), Bits{T, F, F, F, F}},
+		{"Or, lengths differ", b.Or(a), Bits{T, T, T, F, T}},
+		{"Xor, lengths differ", a.Xor(b), Bits{F, T, T, F, T}},
+		{"Not", a.Not(), Bits{F, F, T, T, F}},
+		{"ShiftLeft", a.ShiftLeft(2), Bits{F, F, T, T, F}},
+		{"ShiftLeft, negative", a.ShiftLeft(-1), Bits{T, F, F, T, F}},
+		{"ShiftLeft, past the end", a.ShiftLeft(5), Bits{F, F, F, F, F}},
+		{"ShiftRight", a.ShiftRight(1), Bits{T, F, F, T, F}},
+		{"Rotate", a.Rotate(2), Bits{F, T, T, T, F}},
+		{"Rotate, negative", a.Rotate(-1), Bits{T, F, F, T, T}},
+		{"Rotate, full turn", a.Rotate(5), a},
+		{"OnesCount", a.OnesCount(), 3},
+		{"LeadingZeros", b.LeadingZeros(), 0},
+		{"LeadingZeros, zeros", Bits{T, F, F}.LeadingZeros(), 2},
+		{"LeadingZeros, empty", Bits{}.LeadingZeros(), 0},
+		{"TrailingZeros", Bits{F, F, T}.TrailingZeros(), 2},
+		{"TrailingZeros, all zeros", Bits{F, F, F}.TrailingZeros(), 3},
+		{"Reverse", a.Reverse(), Bits{T, F, F, T, T}},
+		{"empty", Bits{}.Rotate(3), Bits{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.got)
+		})
+	}
+
+	// the operands are unchanged
+	assert.Equal(t, Bits{T, T, F, F, T}, a)
+
+	// values wider than 64 bits work too
+	wide := make(Bits, 100)
+	wide[99] = T
+
+	assert.Equal(t, 99, wide.TrailingZeros())
+	assert.Equal(t, 99, wide.ShiftRight(99).LeadingZeros())
+	assert.Equal(t, uint(1), wide.ShiftRight(99).AsUInt())
+	assert.Equal(t, uint(1)<<63, wide.Rotate(-36).AsUInt())
+}
+
+func TestBitVector_Algebra(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	lengths := []int{0, 1, 5, 63, 64, 65, 127, 128, 200}
+
+	for _, n := range lengths {
+		a := randomBits(rng, n)
+		va := BitVectorFromBits(a)
+
+		for _, m := range lengths {
+			b := randomBits(rng, m)
+			vb := BitVectorFromBits(b)
+
+			assert.Equal(t, a.And(b), va.And(vb).Bits(), "%v and %v bits: And", n, m)
+			assert.Equal(t, a.Or(b), va.Or(vb).Bits(), "%v and %v bits: Or", n, m)
+			assert.Equal(t, a.Xor(b), va.Xor(vb).Bits(), "%v and %v bits: Xor", n, m)
+		}
+
+		assert.Equal(t, a.Not(), va.Not().Bits(), "%v bits: Not", n)
+		assert.Equal(t, a.OnesCount(), va.OnesCount(), "%v bits: OnesCount", n)
+		assert.Equal(t, a.Reverse(), va.Reverse().Bits(), "%v bits: Reverse", n)
+		assert.Equal(t, n-va.Not().OnesCount(), va.OnesCount(), "%v bits: the bits past the length must stay zero", n)
+
+		for _, shift := range []int{-130, -65, -64, -3, 0, 1, 3, 63, 64, 65, 130, 250} {
+			assert.Equal(t, a.ShiftLeft(shift), va.ShiftLeft(shift).Bits(), "%v bits: ShiftLeft(%v)", n, shift)
+			assert.Equal(t, a.ShiftRight(shift), va.ShiftRight(shift).Bits(), "%v bits: ShiftRight(%v)", n, shift)
+			assert.Equal(t, a.Rotate(shift), va.Rotate(shift).Bits(), "%v bits: Rotate(%v)", n, shift)
+		}
+
+		for _, b := range []Bits{a, make(Bits, n), a.ShiftLeft(n / 2), a.ShiftRight(n / 3)} {
+			vb := BitVectorFromBits(b)
+
+			assert.Equal(t, b.LeadingZeros(), vb.LeadingZeros(), "%v bits: LeadingZeros", n)
+			assert.Equal(t, b.TrailingZeros(), vb.TrailingZeros(), "%v bits: TrailingZeros", n)
+		}
+	}
+}
@@ -102,6 +102,68 @@ func (b Bits) AsUInt() uint {
 	return result
 }
 
+// AsUInt64MSB interprets the bits as an unsigned 64-bit integer, most-significant bit first,
+// so that index 0 is the highest bit of the value, as in MSB-first formats such as PNG, JPEG
+// or network headers. Beyond 64 bits, the last 64 bits are kept.
+func (b Bits) AsUInt64MSB() uint64 {
+	result := uint64(0)
+
+	for idx := 0; idx < len(b); idx++ {
+		result <<= 1
+
+		if b[idx] {
+			result |= 1
+		}
+	}
+
+	return result
+}
+
+// AsInt8MSB interprets the bits as a signed 8-bit integer, most-significant bit first
+func (b Bits) AsInt8MSB() int8 {
+	return int8(makeSigned64(uint64(b.AsUInt8MSB()), len(b)))
+}
+
+// AsUInt8MSB interprets the bits as an unsigned 8-bit integer, most-significant bit first
+func (b Bits) AsUInt8MSB() uint8 {
+	return uint8(b.AsUInt64MSB())
+}
+
+// AsInt16MSB interprets the bits as a signed 16-bit integer, most-significant bit first
+func (b Bits) AsInt16MSB() int16 {
+	return int16(makeSigned64(uint64(b.AsUInt16MSB()), len(b)))
+}
+
+// AsUInt16MSB interprets the bits as an unsigned 16-bit integer, most-significant bit first
+func (b Bits) AsUInt16MSB() uint16 {
+	return uint16(b.AsUInt64MSB())
+}
+
+// AsInt32MSB interprets the bits as a signed 32-bit integer, most-significant bit first
+func (b Bits) AsInt32MSB() int32 {
+	return int32(makeSigned64(uint64(b.AsUInt32MSB()), len(b)))
+}
+
+// AsUInt32MSB interprets the bits as an unsigned 32-bit integer, most-significant bit first
+func (b Bits) AsUInt32MSB() uint32 {
+	return uint32(b.AsUInt64MSB())
+}
+
+// AsInt64MSB interprets the bits as a signed 64-bit integer, most-significant bit first
+func (b Bits) AsInt64MSB() int64 {
+	return makeSigned64(b.AsUInt64MSB(), len(b))
+}
+
+// AsIntMSB interprets the bits as a signed integer, most-significant bit first
+func (b Bits) AsIntMSB() int {
+	return int(makeSigned64(uint64(b.AsUIntMSB()), len(b)))
+}
+
+// AsUIntMSB interprets the bits as an unsigned integer, most-significant bit first
+func (b Bits) AsUIntMSB() uint {
+	return uint(b.AsUInt64MSB())
+}
+
 func makeSigned64(unsignedValue uint64, signBitIndex int) int64 {
 	if signBitIndex == 0 {
 		return 0
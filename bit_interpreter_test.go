@@ -291,3 +291,89 @@ func TestBits_AsBool(t *testing.T) {
 		})
 	}
 }
+
+func TestBits_AsUIntMSB(t *testing.T) {
+	tests := []struct {
+		name string
+		b    Bits
+		want uint
+	}{
+		{"empty", Bits{}, 0},
+		{"1 (2 bits)", Bits{F, T}, 1},
+		{"2 (2 bits)", Bits{T, F}, 2},
+		{"0xB2 (8 bits)", Bits{T, F, T, T, F, F, T, F}, 0xB2},
+		{"0x165 (9 bits)", Bits{T, F, T, T, F, F, T, F, T}, 0x165},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.b.AsUIntMSB(); got != tt.want {
+				t.Errorf("AsUIntMSB() = %v, want %v", got, tt.want)
+			}
+
+			if got := tt.b.Reverse().AsUInt(); got != tt.want {
+				t.Errorf("Reverse().AsUInt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBits_AsIntMSB(t *testing.T) {
+	tests := []struct {
+		name string
+		b    Bits
+		want int
+	}{
+		{"empty", Bits{}, 0},
+		// MSB is ON THE LEFT here
+		{"negative 1 (4-bit)", Bits{T, T, T, T}, -1},
+		{"negative 7 (8 bit)", Bits{T, T, T, T, T, F, F, T}, -7},
+		{"positive 7 (4 bit)", Bits{F, T, T, T}, 7},
+		{"negative 8 (4 bit)", Bits{T, F, F, F}, -8},
+		{"negative 1 (1 bit)", Bits{T}, -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.b.AsIntMSB(); got != tt.want {
+				t.Errorf("AsIntMSB() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBits_AsSizedMSB(t *testing.T) {
+	b16 := Bits{T, F, F, F, F, F, F, F, F, F, F, F, F, F, F, T} // 0x8001
+	b32 := append(Bits{T}, make(Bits, 31)...)                   // 0x80000000
+	b64 := append(make(Bits, 63), T)                            // 1
+
+	if got := b16.AsUInt16MSB(); got != 0x8001 {
+		t.Errorf("AsUInt16MSB() = %#x, want 0x8001", got)
+	}
+
+	if got := b16.AsInt16MSB(); got != math.MinInt16+1 {
+		t.Errorf("AsInt16MSB() = %v, want %v", got, math.MinInt16+1)
+	}
+
+	if got := b16[:8].AsUInt8MSB(); got != 0x80 {
+		t.Errorf("AsUInt8MSB() = %#x, want 0x80", got)
+	}
+
+	if got := b16[:8].AsInt8MSB(); got != math.MinInt8 {
+		t.Errorf("AsInt8MSB() = %v, want %v", got, math.MinInt8)
+	}
+
+	if got := b32.AsUInt32MSB(); got != 0x80000000 {
+		t.Errorf("AsUInt32MSB() = %#x, want 0x80000000", got)
+	}
+
+	if got := b32.AsInt32MSB(); got != math.MinInt32 {
+		t.Errorf("AsInt32MSB() = %v, want %v", got, math.MinInt32)
+	}
+
+	if got := b64.AsUInt64MSB(); got != 1 {
+		t.Errorf("AsUInt64MSB() = %v, want 1", got)
+	}
+
+	if got := b64.Not().AsInt64MSB(); got != -2 {
+		t.Errorf("AsInt64MSB() = %v, want -2", got)
+	}
+}
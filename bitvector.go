@@ -0,0 +1,292 @@
+package bitstream
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// BitVector is a packed sequence of bits, a compact alternative to Bits which uses one bit of
+// memory per bit instead of one byte. As with Bits, index 0 is the first bit of the stream, and
+// the least-significant bit when the bits are interpreted as a number.
+//
+// Like a slice, a copy of a BitVector shares its bits.
+type BitVector struct {
+	words  []uint64 // bit i is bit (i % 64) of words[i / 64]; the bits past the length are zero
+	length int
+}
+
+// NewBitVector creates a BitVector of n zero bits
+func NewBitVector(n int) BitVector {
+	return BitVector{
+		words:  make([]uint64, (n+bitsPerWord-1)/bitsPerWord),
+		length: n,
+	}
+}
+
+// BitVectorFromBits packs the given Bits into a BitVector
+func BitVectorFromBits(b Bits) BitVector {
+	v := NewBitVector(len(b))
+
+	for idx := range b {
+		if b[idx] {
+			v.words[idx/bitsPerWord] |= 1 << uint(idx%bitsPerWord)
+		}
+	}
+
+	return v
+}
+
+// BitVectorFromBytes creates a BitVector of the bits of the given bytes, in the order that a
+// little-endian Reader reads them, so that AsBytes yields the same bytes
+func BitVectorFromBytes(b []byte) BitVector {
+	v := NewBitVector(len(b) * bitsPerByte)
+
+	for idx := range v.words {
+		if len(b) >= bytesPerWord {
+			v.words[idx] = binary.LittleEndian.Uint64(b)
+			b = b[bytesPerWord:]
+
+			continue
+		}
+
+		for byteIdx := range b {
+			v.words[idx] |= uint64(b[byteIdx]) << uint(byteIdx*bitsPerByte)
+		}
+	}
+
+	return v
+}
+
+// Len returns the number of bits
+func (v BitVector) Len() int {
+	return v.length
+}
+
+// Bit returns the bit at the given index. It panics if the index is out of range, like a slice.
+func (v BitVector) Bit(i int) bool {
+	if i < 0 || i >= v.length {
+		panic("bitstream.BitVector.Bit: index out of range")
+	}
+
+	return (v.words[i/bitsPerWord]>>uint(i%bitsPerWord))&1 == 1
+}
+
+// Set sets the bit at the given index. It panics if the index is out of range, like a slice.
+func (v BitVector) Set(i int, b bool) {
+	if i < 0 || i >= v.length {
+		panic("bitstream.BitVector.Set: index out of range")
+	}
+
+	mask := uint64(1) << uint(i%bitsPerWord)
+
+	if b {
+		v.words[i/bitsPerWord] |= mask
+	} else {
+		v.words[i/bitsPerWord] &^= mask
+	}
+}
+
+// Words returns the packed bits, 64 to a word, sharing them with the BitVector.
+// Bit i is bit (i % 64) of word (i / 64), and the bits past Len are zero.
+func (v BitVector) Words() []uint64 {
+	return v.words
+}
+
+// Bits unpacks the BitVector into Bits
+func (v BitVector) Bits() Bits {
+	b := make(Bits, v.length)
+
+	for idx := range b {
+		b[idx] = (v.words[idx/bitsPerWord]>>uint(idx%bitsPerWord))&1 == 1
+	}
+
+	return b
+}
+
+// AsBool interprets the bits as a bool
+func (v BitVector) AsBool() bool {
+	return v.AsUInt() > 0
+}
+
+// AsByte interprets the bits as a byte
+func (v BitVector) AsByte() byte {
+	return byte(v.AsUInt())
+}
+
+// AsBytes interprets the bits as a slice of bytes
+func (v BitVector) AsBytes() []byte {
+	result := make([]byte, (v.length+bitsPerByte-1)/bitsPerByte)
+
+	for idx := range result {
+		result[idx] = byte(v.words[idx/bytesPerWord] >> uint(idx%bytesPerWord*bitsPerByte))
+	}
+
+	return result
+}
+
+// AsInt8 interprets the bits as a signed 8-bit integer
+func (v BitVector) AsInt8() int8 {
+	return int8(makeSigned64(uint64(v.AsUInt8()), v.length))
+}
+
+// AsUInt8 interprets the bits as an unsigned 8-bit integer
+func (v BitVector) AsUInt8() uint8 {
+	return v.AsByte()
+}
+
+// AsInt16 interprets the bits as a signed 16-bit integer
+func (v BitVector) AsInt16() int16 {
+	return int16(makeSigned64(uint64(v.AsUInt16()), v.length))
+}
+
+// AsUInt16 interprets the bits as an unsigned 16-bit integer
+func (v BitVector) AsUInt16() uint16 {
+	return uint16(v.AsUInt())
+}
+
+// AsInt32 interprets the bits as a signed 32-bit integer
+func (v BitVector) AsInt32() int32 {
+	return int32(makeSigned64(uint64(v.AsUInt32()), v.length))
+}
+
+// AsUInt32 interprets the bits as an unsigned 32-bit integer
+func (v BitVector) AsUInt32() uint32 {
+	return uint32(v.AsUInt())
+}
+
+// AsInt64 interprets the bits as a signed 64-bit integer
+func (v BitVector) AsInt64() int64 {
+	return makeSigned64(v.AsUInt64(), v.length)
+}
+
+// AsUInt64 interprets the bits as an unsigned 64-bit integer
+func (v BitVector) AsUInt64() uint64 {
+	if v.length == 0 {
+		return 0
+	}
+
+	return v.words[0]
+}
+
+// AsInt interprets the bits as a signed integer
+func (v BitVector) AsInt() int {
+	return int(makeSigned64(uint64(v.AsUInt()), v.length))
+}
+
+// AsUInt interprets the bits as an unsigned integer
+func (v BitVector) AsUInt() uint {
+	return uint(v.AsUInt64())
+}
+
+// AsUInt64MSB interprets the bits as an unsigned 64-bit integer, most-significant bit first,
+// see Bits.AsUInt64MSB
+func (v BitVector) AsUInt64MSB() uint64 {
+	switch {
+	case v.length == 0:
+		return 0
+	case v.length <= bitsPerWord:
+		return bits.Reverse64(v.words[0]) >> uint(bitsPerWord-v.length)
+	default:
+		return v.Bits()[v.length-bitsPerWord:].AsUInt64MSB()
+	}
+}
+
+// AsInt8MSB interprets the bits as a signed 8-bit integer, most-significant bit first
+func (v BitVector) AsInt8MSB() int8 {
+	return int8(makeSigned64(uint64(v.AsUInt8MSB()), v.length))
+}
+
+// AsUInt8MSB interprets the bits as an unsigned 8-bit integer, most-significant bit first
+func (v BitVector) AsUInt8MSB() uint8 {
+	return uint8(v.AsUInt64MSB())
+}
+
+// AsInt16MSB interprets the bits as a signed 16-bit integer, most-significant bit first
+func (v BitVector) AsInt16MSB() int16 {
+	return int16(makeSigned64(uint64(v.AsUInt16MSB()), v.length))
+}
+
+// AsUInt16MSB interprets the bits as an unsigned 16-bit integer, most-significant bit first
+func (v BitVector) AsUInt16MSB() uint16 {
+	return uint16(v.AsUInt64MSB())
+}
+
+// AsInt32MSB interprets the bits as a signed 32-bit integer, most-significant bit first
+func (v BitVector) AsInt32MSB() int32 {
+	return int32(makeSigned64(uint64(v.AsUInt32MSB()), v.length))
+}
+
+// AsUInt32MSB interprets the bits as an unsigned 32-bit integer, most-significant bit first
+func (v BitVector) AsUInt32MSB() uint32 {
+	return uint32(v.AsUInt64MSB())
+}
+
+// AsInt64MSB interprets the bits as a signed 64-bit integer, most-significant bit first
+func (v BitVector) AsInt64MSB() int64 {
+	return makeSigned64(v.AsUInt64MSB(), v.length)
+}
+
+// AsIntMSB interprets the bits as a signed integer, most-significant bit first
+func (v BitVector) AsIntMSB() int {
+	return int(makeSigned64(uint64(v.AsUIntMSB()), v.length))
+}
+
+// AsUIntMSB interprets the bits as an unsigned integer, most-significant bit first
+func (v BitVector) AsUIntMSB() uint {
+	return uint(v.AsUInt64MSB())
+}
+
+// BitVector will read a number of bits from the stream into a BitVector, packing them
+// a word at a time, so that large reads take an eighth of the memory of Bits.
+//
+// NOTE: The number is specified by calling bitstream.Next
+//
+// If fewer bits could be read, the BitVector holds the bits that were read followed by zeros,
+// and the error is as for Bits.
+func (bs *Reader) BitVector() (BitVector, error) {
+	v := NewBitVector(bs.unitsToRead)
+
+	if err := bs.pendingErr(); err != nil {
+		return v, err
+	}
+
+	offset := bs.bitOffset()
+
+	for idx := range v.words {
+		numBits := v.length - idx*bitsPerWord
+		if numBits > bitsPerWord {
+			numBits = bitsPerWord
+		}
+
+		word, numRead, err := bs.lookahead(bs.bitOffset(), numBits)
+
+		v.words[idx] = word
+
+		bs.advance(numRead)
+
+		if err != nil {
+			err = &ShortReadError{Requested: v.length, Read: idx*bitsPerWord + numRead, BitOffset: offset, Err: err}
+			return v, bs.fail(offset, err)
+		}
+	}
+
+	return v, nil
+}
+
+// WriteBitVector writes the given BitVector, a word at a time
+func (w *Writer) WriteBitVector(v BitVector) (bitsWritten int, err error) {
+	for idx := 0; idx < len(v.words) && err == nil; idx++ {
+		numBits := v.length - idx*bitsPerWord
+		if numBits > bitsPerWord {
+			numBits = bitsPerWord
+		}
+
+		var numWritten int
+
+		numWritten, err = w.writeWord(v.words[idx], numBits)
+
+		bitsWritten += numWritten
+	}
+
+	return bitsWritten, err
+}
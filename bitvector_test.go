@@ -0,0 +1,140 @@
+package bitstream
+
+import (
+	"errors"
+	"io"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func randomBits(rng *rand.Rand, n int) Bits {
+	b := make(Bits, n)
+
+	for idx := range b {
+		b[idx] = rng.Intn(2) == 1
+	}
+
+	return b
+}
+
+func TestBitVector_Interpret(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	for n := 0; n <= 130; n++ {
+		b := randomBits(rng, n)
+		v := BitVectorFromBits(b)
+
+		assert.Equal(t, n, v.Len(), "unexpected length")
+		assert.Equal(t, b, v.Bits(), "%v bits: Bits", n)
+		assert.Equal(t, b.AsBool(), v.AsBool(), "%v bits: AsBool", n)
+		assert.Equal(t, b.AsByte(), v.AsByte(), "%v bits: AsByte", n)
+		assert.Equal(t, b.AsBytes(), v.AsBytes(), "%v bits: AsBytes", n)
+		assert.Equal(t, b.AsInt8(), v.AsInt8(), "%v bits: AsInt8", n)
+		assert.Equal(t, b.AsUInt8(), v.AsUInt8(), "%v bits: AsUInt8", n)
+		assert.Equal(t, b.AsInt16(), v.AsInt16(), "%v bits: AsInt16", n)
+		assert.Equal(t, b.AsUInt16(), v.AsUInt16(), "%v bits: AsUInt16", n)
+		assert.Equal(t, b.AsInt32(), v.AsInt32(), "%v bits: AsInt32", n)
+		assert.Equal(t, b.AsUInt32(), v.AsUInt32(), "%v bits: AsUInt32", n)
+		assert.Equal(t, b.AsInt64(), v.AsInt64(), "%v bits: AsInt64", n)
+		assert.Equal(t, b.AsUInt64(), v.AsUInt64(), "%v bits: AsUInt64", n)
+		assert.Equal(t, b.AsInt(), v.AsInt(), "%v bits: AsInt", n)
+		assert.Equal(t, b.AsUInt(), v.AsUInt(), "%v bits: AsUInt", n)
+		assert.Equal(t, b.AsInt8MSB(), v.AsInt8MSB(), "%v bits: AsInt8MSB", n)
+		assert.Equal(t, b.AsUInt8MSB(), v.AsUInt8MSB(), "%v bits: AsUInt8MSB", n)
+		assert.Equal(t, b.AsInt16MSB(), v.AsInt16MSB(), "%v bits: AsInt16MSB", n)
+		assert.Equal(t, b.AsUInt16MSB(), v.AsUInt16MSB(), "%v bits: AsUInt16MSB", n)
+		assert.Equal(t, b.AsInt32MSB(), v.AsInt32MSB(), "%v bits: AsInt32MSB", n)
+		assert.Equal(t, b.AsUInt32MSB(), v.AsUInt32MSB(), "%v bits: AsUInt32MSB", n)
+		assert.Equal(t, b.AsInt64MSB(), v.AsInt64MSB(), "%v bits: AsInt64MSB", n)
+		assert.Equal(t, b.AsUInt64MSB(), v.AsUInt64MSB(), "%v bits: AsUInt64MSB", n)
+		assert.Equal(t, b.AsIntMSB(), v.AsIntMSB(), "%v bits: AsIntMSB", n)
+		assert.Equal(t, b.AsUIntMSB(), v.AsUIntMSB(), "%v bits: AsUIntMSB", n)
+	}
+}
+
+func TestBitVector_Access(t *testing.T) {
+	data := []byte{0x01, 0x80, 0xFF, 0x00, 0x12, 0x34, 0x56, 0x78, 0x9A}
+	v := BitVectorFromBytes(data)
+
+	assert.Equal(t, 72, v.Len(), "unexpected length")
+	assert.Equal(t, data, v.AsBytes(), "unexpected bytes")
+	assert.Equal(t, uint64(0x78563412_00FF8001), v.Words()[0], "unexpected first word")
+	assert.True(t, v.Bit(0), "expected bit 0 to be set")
+	assert.False(t, v.Bit(1), "expected bit 1 to be clear")
+	assert.True(t, v.Bit(15), "expected bit 15 to be set")
+
+	v.Set(1, true)
+	v.Set(0, false)
+	v.Set(71, false)
+
+	assert.Equal(t, []byte{0x02, 0x80, 0xFF, 0x00, 0x12, 0x34, 0x56, 0x78, 0x1A}, v.AsBytes(), "unexpected bytes after Set")
+	assert.Panics(t, func() { v.Bit(72) }, "expected a panic out of range")
+	assert.Panics(t, func() { v.Set(-1, true) }, "expected a panic out of range")
+}
+
+func TestReader_BitVector(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	data := make([]byte, 100)
+	rng.Read(data)
+
+	for _, offset := range []int{0, 3, 64} {
+		for _, n := range []int{0, 1, 13, 64, 65, 200} {
+			expected := ReaderFromBytes(data...)
+			_ = expected.Next(offset).Bits()
+
+			r := ReaderFromBytes(data...)
+			_ = r.Next(offset).Bits()
+
+			v, err := r.Next(n).BitVector()
+
+			assert.NoError(t, err)
+			assert.Equal(t, expected.Next(n).Bits().Bits, v.Bits(), "offset %v, %v bits", offset, n)
+			assert.Equal(t, int64(offset+n), r.BitOffset(), "unexpected bit offset")
+		}
+	}
+
+	// a packed read takes a bit of memory per bit
+	v, err := ReaderFromBytes(make([]byte, 1<<20)...).Next(8 << 20).BitVector()
+
+	assert.NoError(t, err)
+	assert.Equal(t, (8<<20)/64, len(v.Words()), "unexpected number of words")
+
+	v, err = ReaderFromBytes(0xFF, 0xFF).Next(20).BitVector()
+
+	var shortRead *ShortReadError
+	if assert.True(t, errors.As(err, &shortRead), "expected a *ShortReadError, got %v", err) {
+		assert.Equal(t, 16, shortRead.Read, "unexpected number of bits read")
+	}
+
+	assert.True(t, errors.Is(err, io.EOF), "expected io.EOF")
+	assert.Equal(t, uint64(0xFFFF), v.AsUInt64(), "expected the bits which were read")
+}
+
+func TestWriter_WriteBitVector(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	for _, n := range []int{0, 1, 13, 64, 65, 200} {
+		b := randomBits(rng, n)
+
+		expected := &Writer{}
+		_, _ = expected.WriteBits(Bits{T, F, T})
+		_, _ = expected.WriteBits(b)
+
+		w := &Writer{}
+		_, _ = w.WriteBits(Bits{T, F, T})
+
+		bitsWritten, err := w.WriteBitVector(BitVectorFromBits(b))
+
+		assert.NoError(t, err)
+		assert.Equal(t, n, bitsWritten, "unexpected number of bits written")
+		assert.Equal(t, expected.Bytes(), w.Bytes(), "%v bits", n)
+
+		bitsWritten, err = w.Write(BitVectorFromBits(b))
+
+		assert.NoError(t, err)
+		assert.Equal(t, n, bitsWritten, "unexpected number of bits written by Write")
+	}
+}
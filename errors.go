@@ -0,0 +1,121 @@
+package bitstream
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrNotSeekable is returned when a Reader over a forward-only source is asked
+	// to move backward, or to do something that requires knowing the whole stream.
+	ErrNotSeekable = errors.New("stream is not seekable")
+
+	// ErrNegativeOffset is returned when a position or length before the start of the stream is given
+	ErrNegativeOffset = errors.New("negative offset")
+
+	// ErrInvalidWhence is returned when a seek is given a whence other than io.SeekStart,
+	// io.SeekCurrent or io.SeekEnd
+	ErrInvalidWhence = errors.New("invalid whence")
+
+	// ErrInvalidMark is returned when a Marker is used after it was reset or discarded,
+	// or with a Reader other than the one which created it.
+	ErrInvalidMark = errors.New("invalid mark")
+
+	// ErrOverflow is returned when a value does not fit in the requested number of bits
+	ErrOverflow = errors.New("value overflows bit width")
+
+	// ErrInvalidWidth is returned when a bit width is negative, or wider than 64 bits
+	ErrInvalidWidth = errors.New("invalid bit width")
+
+	// ErrPlaceholderFilled is returned when a Placeholder is filled more than once
+	ErrPlaceholderFilled = errors.New("placeholder already filled")
+
+	// ErrUnfilledPlaceholder is returned when a Writer is closed before all of its placeholders are filled
+	ErrUnfilledPlaceholder = errors.New("placeholder not filled")
+
+	// ErrClosed is returned when writing to a Writer after it was closed
+	ErrClosed = errors.New("writer is closed")
+
+	// ErrSyntax is returned when ParseBits is given text which is not a binary or hexadecimal literal
+	ErrSyntax = errors.New("invalid syntax")
+
+	// ErrUnsupportedType is returned when Writer.Write is given a value it can not write
+	ErrUnsupportedType = errors.New("unsupported type")
+)
+
+// ShortReadError is returned when fewer bits could be read than were requested.
+// Err is the cause, usually io.EOF, so errors.Is(err, io.EOF) holds for a read past the end.
+type ShortReadError struct {
+	Requested int   // the number of bits requested
+	Read      int   // the number of bits which were read
+	BitOffset int64 // the bit offset at which the read started
+	Err       error
+}
+
+func (e *ShortReadError) Error() string {
+	return fmt.Sprintf("error reading bits: read %v of %v bits at bit offset %v: %v",
+		e.Read, e.Requested, e.BitOffset, e.Err)
+}
+
+func (e *ShortReadError) Unwrap() error {
+	return e.Err
+}
+
+// SeekError is returned when the read position can not be moved. Offset and Whence are the
+// target of the seek; Offset is in bytes for Reader.Seek, and in bits for Reader.SeekBit and
+// the bit position methods. Err is the cause, such as ErrNegativeOffset or ErrNotSeekable.
+type SeekError struct {
+	Offset int64
+	Whence int
+	Err    error
+}
+
+func (e *SeekError) Error() string {
+	return fmt.Sprintf("error seeking Bitstream: offset %v, whence %v: %v", e.Offset, e.Whence, e.Err)
+}
+
+func (e *SeekError) Unwrap() error {
+	return e.Err
+}
+
+// PaddingError is returned by a Reader with strict padding when skipped bits do not match
+// the padding pattern. The skipped bits are still consumed.
+type PaddingError struct {
+	BitOffset int64 // the bit offset at which the padding starts
+	Length    int   // the number of padding bits
+	Index     int   // the index within the padding of the first bit which does not match
+}
+
+func (e *PaddingError) Error() string {
+	return fmt.Sprintf("invalid padding: bit %v of the %v bits at bit offset %v does not match",
+		e.Index, e.Length, e.BitOffset)
+}
+
+// ReadError is the first error encountered by a sticky Reader, see Reader.SetSticky
+type ReadError struct {
+	BitOffset int64 // the bit offset at which the failed read started
+	Err       error
+}
+
+func (e *ReadError) Error() string {
+	return fmt.Sprintf("error at bit offset %v: %v", e.BitOffset, e.Err)
+}
+
+func (e *ReadError) Unwrap() error {
+	return e.Err
+}
+
+// ArgumentError is returned by Writer.Write when an argument can not be written.
+// The arguments before Index were written.
+type ArgumentError struct {
+	Index int // the index of the argument which failed
+	Err   error
+}
+
+func (e *ArgumentError) Error() string {
+	return fmt.Sprintf("error writing argument, index %v: %v", e.Index, e.Err)
+}
+
+func (e *ArgumentError) Unwrap() error {
+	return e.Err
+}
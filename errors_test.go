@@ -0,0 +1,97 @@
+package bitstream
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShortReadError(t *testing.T) {
+	bs := ReaderFromBytes(1, 2)
+
+	_ = bs.Next(5).Bits()
+
+	res := bs.Next(3).Bytes()
+
+	var shortRead *ShortReadError
+	if !errors.As(res.Error, &shortRead) {
+		t.Fatalf("expected a *ShortReadError, got %v", res.Error)
+	}
+
+	assert.Equal(t, 24, shortRead.Requested, "unexpected number of bits requested")
+	assert.Equal(t, 11, shortRead.Read, "unexpected number of bits read")
+	assert.Equal(t, int64(5), shortRead.BitOffset, "unexpected bit offset")
+	assert.True(t, errors.Is(res.Error, io.EOF), "expected the error to wrap io.EOF")
+
+	_, err := bs.Sub(0, 3).Next(100).Bits().AsUInt()
+	assert.True(t, errors.As(err, &shortRead), "expected a *ShortReadError at the end of a window, got %v", err)
+	assert.Equal(t, 3, shortRead.Read, "unexpected number of bits read")
+
+	_, err = bs.SetSticky(true).Next(1).Bits().AsUInt()
+	assert.True(t, errors.As(err, &shortRead), "expected a *ShortReadError from a sticky Reader, got %v", err)
+}
+
+func TestSeekError(t *testing.T) {
+	tests := []struct {
+		name    string
+		seek    func(bs *Reader) (int64, error)
+		offset  int64
+		whence  int
+		wantErr error
+	}{
+		{"negative byte offset", func(bs *Reader) (int64, error) {
+			return bs.Seek(-2, io.SeekCurrent)
+		}, -2, io.SeekCurrent, ErrNegativeOffset},
+		{"negative bit offset", func(bs *Reader) (int64, error) {
+			return bs.SeekBit(-20, io.SeekEnd)
+		}, -20, io.SeekEnd, ErrNegativeOffset},
+		{"bad whence", func(bs *Reader) (int64, error) {
+			return bs.SeekBit(1, 7)
+		}, 1, 7, ErrInvalidWhence},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.seek(ReaderFromBytes(1, 2))
+
+			var seekErr *SeekError
+			if !errors.As(err, &seekErr) {
+				t.Fatalf("expected a *SeekError, got %v", err)
+			}
+
+			assert.Equal(t, tt.offset, seekErr.Offset, "unexpected offset")
+			assert.Equal(t, tt.whence, seekErr.Whence, "unexpected whence")
+			assert.True(t, errors.Is(err, tt.wantErr), "expected %v, got %v", tt.wantErr, err)
+		})
+	}
+
+	bs := NewReader(forwardOnly{ReaderFromBytes(1, 2).stream})
+	_ = bs.Next(9).Bits()
+
+	_, err := bs.SetBitPosition(0).Next(1).Bits().AsUInt()
+
+	var seekErr *SeekError
+	if !errors.As(err, &seekErr) {
+		t.Fatalf("expected a *SeekError, got %v", err)
+	}
+
+	assert.Equal(t, int64(8), seekErr.Offset, "unexpected offset")
+	assert.True(t, errors.Is(err, ErrNotSeekable), "expected ErrNotSeekable, got %v", err)
+}
+
+func TestSentinelErrors(t *testing.T) {
+	bs := ReaderFromBytes(1)
+
+	m := bs.Mark()
+	_ = bs.Discard(m)
+
+	assert.True(t, errors.Is(bs.Reset(m), ErrInvalidMark), "expected ErrInvalidMark")
+
+	_, err := bs.Sub(-1, 1).Next(1).Bits().AsUInt()
+	assert.True(t, errors.Is(err, ErrNegativeOffset), "expected ErrNegativeOffset, got %v", err)
+
+	_, err = (&Writer{}).Write(struct{}{})
+	assert.True(t, errors.Is(err, ErrUnsupportedType), "expected ErrUnsupportedType, got %v", err)
+}
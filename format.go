@@ -0,0 +1,355 @@
+package bitstream
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// String returns the bits as a binary literal, most-significant bit first, such as "0b1011"
+// for Bits{true, true, false, true}. Every bit is written, so the length is kept, and
+// ParseBits reads the result back.
+func (b Bits) String() string {
+	return "0b" + b.digits(1, false, false)
+}
+
+// Format implements fmt.Formatter. The %b, %x and %X verbs write the bits as binary or
+// hexadecimal digits, most-significant first, as for a number. With the + flag, the digits
+// are in stream order instead, least-significant first; each hexadecimal digit is then the
+// value of the next 4 bits. The # flag adds a 0b or 0x prefix, and for %x or %X of a length
+// which is not a multiple of 4, the length. Without the + flag, ParseBits reads the result back.
+// The %v and %s verbs write String, and %q writes it quoted.
+func (b Bits) Format(f fmt.State, verb rune) {
+	text, ok := b.formatDigits(f, verb)
+
+	switch {
+	case ok:
+	case verb == 'v' || verb == 's':
+		text = b.String()
+	case verb == 'q':
+		text = strconv.Quote(b.String())
+	default:
+		text = fmt.Sprintf("%%!%c(bitstream.Bits=%s)", verb, b.String())
+	}
+
+	writePadded(f, text)
+}
+
+// formatDigits formats the bits for the %b, %x and %X verbs, see Format.
+// It returns false for any other verb.
+func (b Bits) formatDigits(f fmt.State, verb rune) (string, bool) {
+	var text string
+
+	lsbFirst := f.Flag('+')
+
+	switch verb {
+	case 'b':
+		text = b.digits(1, lsbFirst, false)
+
+		if f.Flag('#') {
+			text = "0b" + text
+		}
+	case 'x', 'X':
+		text = b.digits(4, lsbFirst, verb == 'X')
+
+		if f.Flag('#') {
+			text = "0x" + text
+
+			if len(b)%4 != 0 {
+				text += ":" + strconv.Itoa(len(b))
+			}
+		}
+	default:
+		return "", false
+	}
+
+	return text, true
+}
+
+// writePadded writes text to f, padded with spaces to the width of f, if any
+func writePadded(f fmt.State, text string) {
+	if width, ok := f.Width(); ok && len(text) < width {
+		padding := strings.Repeat(" ", width-len(text))
+
+		if f.Flag('-') {
+			text += padding
+		} else {
+			text = padding + text
+		}
+	}
+
+	_, _ = f.Write([]byte(text))
+}
+
+// digits writes the bits as digits of bitsPerDigit bits each, most-significant first unless
+// lsbFirst is true. For a length which is not a multiple of bitsPerDigit, the last digit
+// in stream order is partial.
+func (b Bits) digits(bitsPerDigit int, lsbFirst, upper bool) string {
+	const lower, upperDigits = "0123456789abcdef", "0123456789ABCDEF"
+
+	chars := lower
+	if upper {
+		chars = upperDigits
+	}
+
+	numDigits := (len(b) + bitsPerDigit - 1) / bitsPerDigit
+	text := make([]byte, numDigits)
+
+	for idx := 0; idx < numDigits; idx++ {
+		start, end := idx*bitsPerDigit, (idx+1)*bitsPerDigit
+		if end > len(b) {
+			end = len(b)
+		}
+
+		digit := chars[b[start:end].AsUInt()]
+
+		if lsbFirst {
+			text[idx] = digit
+		} else {
+			text[numDigits-1-idx] = digit
+		}
+	}
+
+	return string(text)
+}
+
+// ParseBits parses a binary or hexadecimal literal, most-significant digit first, as written
+// by String or the %#b and %#x verbs. Underscores may separate the digits. The length is
+// the number of bits in the digits, or can be given after a colon, so that "0x3f:6" is the
+// 6 bits of 0x3f, and "0b101:8" is 0b00000101. The error wraps ErrSyntax for a malformed
+// literal, and ErrOverflow if the value does not fit in the given length.
+//
+// example:
+//
+// b, err := bitstream.ParseBits("0b1011_0010")
+func ParseBits(s string) (Bits, error) {
+	text, length := s, -1
+
+	if idx := strings.LastIndexByte(s, ':'); idx >= 0 {
+		n, err := strconv.Atoi(s[idx+1:])
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("can not parse %q as bits, bad length: %w", s, ErrSyntax)
+		}
+
+		text, length = s[:idx], n
+	}
+
+	if len(text) < 2 || text[0] != '0' {
+		return nil, fmt.Errorf("can not parse %q as bits, expected a 0b or 0x prefix: %w", s, ErrSyntax)
+	}
+
+	var bitsPerDigit int
+
+	switch text[1] {
+	case 'b', 'B':
+		bitsPerDigit = 1
+	case 'x', 'X':
+		bitsPerDigit = 4
+	default:
+		return nil, fmt.Errorf("can not parse %q as bits, expected a 0b or 0x prefix: %w", s, ErrSyntax)
+	}
+
+	digits := strings.Replace(text[2:], "_", "", -1)
+	result := make(Bits, len(digits)*bitsPerDigit)
+
+	for idx := range digits {
+		value, err := strconv.ParseUint(digits[idx:idx+1], 1<<uint(bitsPerDigit), 8)
+		if err != nil {
+			return nil, fmt.Errorf("can not parse %q as bits, bad digit %q: %w", s, digits[idx], ErrSyntax)
+		}
+
+		for bitIdx := 0; bitIdx < bitsPerDigit; bitIdx++ {
+			result[(len(digits)-1-idx)*bitsPerDigit+bitIdx] = (value>>uint(bitIdx))&1 == 1
+		}
+	}
+
+	switch {
+	case length < 0:
+	case length <= len(result):
+		if result[length:].OnesCount() > 0 {
+			return nil, fmt.Errorf("can not parse %q as %v bits: %w", s, length, ErrOverflow)
+		}
+
+		result = result[:length]
+	default:
+		result = append(result, make(Bits, length-len(result))...)
+	}
+
+	return result, nil
+}
+
+// MarshalText implements encoding.TextMarshaler, writing String
+func (b Bits) MarshalText() ([]byte, error) {
+	return []byte(b.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, reading any literal that ParseBits accepts
+func (b *Bits) UnmarshalText(text []byte) error {
+	parsed, err := ParseBits(string(text))
+	if err != nil {
+		return err
+	}
+
+	*b = parsed
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, writing String as a JSON string
+func (b Bits) MarshalJSON() ([]byte, error) {
+	return json.Marshal(b.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler, reading a JSON string that ParseBits accepts
+func (b *Bits) UnmarshalJSON(data []byte) error {
+	var text string
+
+	if err := json.Unmarshal(data, &text); err != nil {
+		return err
+	}
+
+	return b.UnmarshalText([]byte(text))
+}
+
+// String returns the bits as a binary literal, and the error if there is one, such as
+// "0b0000000001 (error: ...)"
+func (res Response) String() string {
+	if res.Error == nil {
+		return res.Bits.String()
+	}
+
+	return res.Bits.String() + " (error: " + res.Error.Error() + ")"
+}
+
+// Format implements fmt.Formatter. The verbs are as for Bits.Format, but the error, if there
+// is one, is written after the bits, as String does.
+func (res Response) Format(f fmt.State, verb rune) {
+	text, ok := res.Bits.formatDigits(f, verb)
+
+	switch {
+	case ok:
+		if res.Error != nil {
+			text += " (error: " + res.Error.Error() + ")"
+		}
+	case verb == 'v' || verb == 's':
+		text = res.String()
+	case verb == 'q':
+		text = strconv.Quote(res.String())
+	default:
+		text = fmt.Sprintf("%%!%c(bitstream.Response=%s)", verb, res.String())
+	}
+
+	writePadded(f, text)
+}
+
+// MarshalText implements encoding.TextMarshaler, writing String
+func (res Response) MarshalText() ([]byte, error) {
+	return []byte(res.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, reading the text written by MarshalText.
+// The error is restored as a plain error with the same message.
+func (res *Response) UnmarshalText(text []byte) error {
+	bitsText, errText := string(text), ""
+
+	if idx := strings.Index(bitsText, " (error: "); idx >= 0 && strings.HasSuffix(bitsText, ")") {
+		bitsText, errText = bitsText[:idx], bitsText[idx+len(" (error: "):len(bitsText)-1]
+	}
+
+	parsed, err := ParseBits(bitsText)
+	if err != nil {
+		return err
+	}
+
+	*res = Response{Bits: parsed, Error: textError(errText)}
+
+	return nil
+}
+
+// responseJSON is the JSON form of a Response
+type responseJSON struct {
+	Bits  Bits   `json:"bits"`
+	Error string `json:"error,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler, writing an object with the bits as a string, as
+// Bits.MarshalJSON does, and the error message, if there is one:
+//
+//	{"bits":"0b0000000001","error":"..."}
+func (res Response) MarshalJSON() ([]byte, error) {
+	encoded := responseJSON{Bits: res.Bits}
+
+	if res.Error != nil {
+		encoded.Error = res.Error.Error()
+	}
+
+	return json.Marshal(encoded)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, reading the object written by MarshalJSON.
+// The error is restored as a plain error with the same message.
+func (res *Response) UnmarshalJSON(data []byte) error {
+	var decoded responseJSON
+
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	*res = Response{Bits: decoded.Bits, Error: textError(decoded.Error)}
+
+	return nil
+}
+
+// textError returns an error with the given message, or nil if it is empty
+func textError(message string) error {
+	if message == "" {
+		return nil
+	}
+
+	return errors.New(message)
+}
+
+// String returns the bits as a binary literal, see Bits.String
+func (v BitVector) String() string {
+	return v.Bits().String()
+}
+
+// Format implements fmt.Formatter, see Bits.Format
+func (v BitVector) Format(f fmt.State, verb rune) {
+	v.Bits().Format(f, verb)
+}
+
+// MarshalText implements encoding.TextMarshaler, writing String
+func (v BitVector) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, reading any literal that ParseBits accepts
+func (v *BitVector) UnmarshalText(text []byte) error {
+	parsed, err := ParseBits(string(text))
+	if err != nil {
+		return err
+	}
+
+	*v = BitVectorFromBits(parsed)
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, writing String as a JSON string
+func (v BitVector) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler, reading a JSON string that ParseBits accepts
+func (v *BitVector) UnmarshalJSON(data []byte) error {
+	var text string
+
+	if err := json.Unmarshal(data, &text); err != nil {
+		return err
+	}
+
+	return v.UnmarshalText([]byte(text))
+}
@@ -0,0 +1,195 @@
+package bitstream
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBits_Format(t *testing.T) {
+	b3 := Bits{T, F, F}
+	b6 := Bits{T, T, T, T, T, T}
+	b8 := Bits{T, T, T, T, T, T, F, T}
+
+	tests := []struct {
+		format string
+		value  interface{}
+		want   string
+	}{
+		{"%v", b3, "0b001"},
+		{"%s", b3, "0b001"},
+		{"%q", b3, `"0b001"`},
+		{"%b", b3, "001"},
+		{"%+b", b3, "100"},
+		{"%#b", b3, "0b001"},
+		{"%x", b8, "bf"},
+		{"%X", b8, "BF"},
+		{"%#x", b8, "0xbf"},
+		{"%+x", b8, "fb"},
+		{"%x", b6, "3f"},
+		{"%#x", b6, "0x3f:6"},
+		{"%+x", b6, "f3"},
+		{"%6b", b3, "   001"},
+		{"%-6b|", b3, "001   |"},
+		{"%v", Bits{}, "0b"},
+		{"%x", Bits{}, ""},
+		{"%d", b3, "%!d(bitstream.Bits=0b001)"},
+		{"%#+x", Bits{T, T, F, F, F}, "0x30:5"},
+		{"%v", BitVectorFromBits(b8), "0b10111111"},
+		{"%#x", BitVectorFromBits(b6), "0x3f:6"},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.want, fmt.Sprintf(test.format, test.value), test.format)
+	}
+}
+
+func TestParseBits(t *testing.T) {
+	tests := []struct {
+		text string
+		want Bits
+		err  error
+	}{
+		{"0b1011_0010", Bits{F, T, F, F, T, T, F, T}, nil},
+		{"0B101", Bits{T, F, T}, nil},
+		{"0x3f:6", Bits{T, T, T, T, T, T}, nil},
+		{"0x3F", Bits{T, T, T, T, T, T, F, F}, nil},
+		{"0b101:5", Bits{T, F, T, F, F}, nil},
+		{"0x0:0", Bits{}, nil},
+		{"0b", Bits{}, nil},
+		{"0x3f:5", nil, ErrOverflow},
+		{"0b102", nil, ErrSyntax},
+		{"0xfg", nil, ErrSyntax},
+		{"0o17", nil, ErrSyntax},
+		{"1011", nil, ErrSyntax},
+		{"0x3f:", nil, ErrSyntax},
+		{"0x3f:-1", nil, ErrSyntax},
+	}
+
+	for _, test := range tests {
+		got, err := ParseBits(test.text)
+
+		if test.err != nil {
+			assert.True(t, errors.Is(err, test.err), "%s: got error %v", test.text, err)
+			continue
+		}
+
+		assert.NoError(t, err, test.text)
+		assert.Equal(t, test.want, got, test.text)
+	}
+}
+
+func TestBits_FormatRoundTrip(t *testing.T) {
+	for n := 0; n <= 17; n++ {
+		b := make(Bits, n)
+		for idx := range b {
+			b[idx] = idx%3 == 0
+		}
+
+		for _, format := range []string{"%v", "%#b", "%#x"} {
+			got, err := ParseBits(fmt.Sprintf(format, b))
+			assert.NoError(t, err)
+			assert.Equal(t, b, got, "%s of %d bits", format, n)
+		}
+	}
+}
+
+func TestBits_JSON(t *testing.T) {
+	type record struct {
+		Flags Bits `json:"flags"`
+	}
+
+	data, err := json.Marshal(record{Flags: Bits{T, F, T, T}})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"flags":"0b1101"}`, string(data))
+
+	var decoded record
+
+	assert.NoError(t, json.Unmarshal([]byte(`{"flags":"0x3f:6"}`), &decoded))
+	assert.Equal(t, Bits{T, T, T, T, T, T}, decoded.Flags)
+
+	assert.Error(t, json.Unmarshal([]byte(`{"flags":"0b2"}`), &decoded))
+	assert.Error(t, json.Unmarshal([]byte(`{"flags":[true]}`), &decoded))
+
+	text, err := Bits{F, T}.MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, "0b10", string(text))
+}
+
+func TestResponse_Format(t *testing.T) {
+	failed := ReaderFromBytes(0x01).Next(10).Bits()
+	read := ReaderFromBytes(0x01).Next(4).Bits()
+
+	tests := []struct {
+		format string
+		value  Response
+		want   string
+	}{
+		{"%v", read, "0b0001"},
+		{"%x", read, "1"},
+		{"%v", failed, "0b0000000001 (error: " + failed.Error.Error() + ")"},
+		{"%s", failed, "0b0000000001 (error: " + failed.Error.Error() + ")"},
+		{"%#x", failed, "0x001:10 (error: " + failed.Error.Error() + ")"},
+		{"%d", read, "%!d(bitstream.Response=0b0001)"},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.want, fmt.Sprintf(test.format, test.value), test.format)
+	}
+
+	assert.Equal(t, failed.String(), fmt.Sprint(failed), "unexpected String")
+}
+
+func TestResponse_Encoding(t *testing.T) {
+	failed := ReaderFromBytes(0x01).Next(10).Bits()
+
+	data, err := json.Marshal(failed)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"bits":"0b0000000001","error":`+fmt.Sprintf("%q", failed.Error.Error())+`}`, string(data))
+
+	var decoded Response
+
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, failed.Bits, decoded.Bits, "unexpected bits from JSON")
+	assert.EqualError(t, decoded.Error, failed.Error.Error(), "unexpected error from JSON")
+
+	data, err = json.Marshal(Response{Bits: Bits{T, F}})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"bits":"0b01"}`, string(data))
+
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, Bits{T, F}, decoded.Bits, "unexpected bits from JSON")
+	assert.NoError(t, decoded.Error, "unexpected error from JSON")
+
+	text, err := failed.MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, failed.String(), string(text))
+
+	assert.NoError(t, decoded.UnmarshalText(text))
+	assert.Equal(t, failed.Bits, decoded.Bits, "unexpected bits from text")
+	assert.EqualError(t, decoded.Error, failed.Error.Error(), "unexpected error from text")
+
+	assert.NoError(t, decoded.UnmarshalText([]byte("0b1")))
+	assert.Equal(t, Bits{T}, decoded.Bits, "unexpected bits from text")
+	assert.NoError(t, decoded.Error, "unexpected error from text")
+}
+
+func TestBitVector_JSON(t *testing.T) {
+	type record struct {
+		Flags BitVector `json:"flags"`
+	}
+
+	data, err := json.Marshal(record{Flags: BitVectorFromBits(Bits{T, F, T, T})})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"flags":"0b1101"}`, string(data))
+
+	var decoded record
+
+	assert.NoError(t, json.Unmarshal([]byte(`{"flags":"0x3f:6"}`), &decoded))
+	assert.Equal(t, Bits{T, T, T, T, T, T}, decoded.Flags.Bits())
+
+	assert.Error(t, json.Unmarshal([]byte(`{"flags":"0b2"}`), &decoded))
+}
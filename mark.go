@@ -0,0 +1,93 @@
+package bitstream
+
+import "fmt"
+
+// Marker is a saved read position of a Reader, see Reader.Mark
+type Marker struct {
+	index  int // the index of the mark within the mark stack
+	serial int // the serial number of the mark, used to detect stale markers
+}
+
+// mark is the state of a Reader that is restored by Reader.Reset
+type mark struct {
+	serial    int
+	offset    int64
+	bitsRead  int
+	err       error
+	stickyErr error
+}
+
+// Mark saves the current read position, the number of bits read and any pending or sticky error,
+// so that they can later be restored with Reset. This allows for speculative parsing:
+//
+//	m := r.Mark()
+//	if err := parseExtended(r); err != nil {
+//		_ = r.Reset(m)
+//		err = parseSimple(r)
+//	} else {
+//		_ = r.Discard(m)
+//	}
+//
+// Marks form a stack; resetting or discarding a Marker also drops every Marker created after it.
+// A forward-only Reader keeps the data after its oldest Marker buffered, so it can be reset too.
+func (bs *Reader) Mark() Marker {
+	bs.markSerial++
+
+	bs.marks = append(bs.marks, mark{
+		serial:    bs.markSerial,
+		offset:    bs.bitOffset(),
+		bitsRead:  bs.bitsRead,
+		err:       bs.err,
+		stickyErr: bs.stickyErr,
+	})
+
+	return Marker{index: len(bs.marks) - 1, serial: bs.markSerial}
+}
+
+// Reset restores the state saved by the given Marker, even if a read has failed since
+// the Marker was created. The Marker, and every Marker created after it, is dropped.
+// The error wraps ErrInvalidMark if the Marker was already dropped.
+func (bs *Reader) Reset(m Marker) error {
+	saved, err := bs.popMark(m)
+	if err != nil {
+		return fmt.Errorf("error resetting Bitstream: %w", err)
+	}
+
+	bs.setBitOffset(saved.offset)
+	bs.bitsRead, bs.err, bs.stickyErr = saved.bitsRead, saved.err, saved.stickyErr
+
+	return nil
+}
+
+// Discard drops the given Marker, and every Marker created after it,
+// without changing the read position. The error wraps ErrInvalidMark
+// if the Marker was already dropped.
+func (bs *Reader) Discard(m Marker) error {
+	if _, err := bs.popMark(m); err != nil {
+		return fmt.Errorf("error discarding mark: %w", err)
+	}
+
+	return nil
+}
+
+// popMark truncates the mark stack at the given Marker, yielding the saved state
+func (bs *Reader) popMark(m Marker) (mark, error) {
+	if m.index < 0 || m.index >= len(bs.marks) || bs.marks[m.index].serial != m.serial {
+		return mark{}, ErrInvalidMark
+	}
+
+	saved := bs.marks[m.index]
+	bs.marks = bs.marks[:m.index]
+
+	return saved, nil
+}
+
+// markedByte returns the byte offset, relative to the start of the stream,
+// of the oldest mark. If there are no marks, it returns -1.
+func (bs *Reader) markedByte() int64 {
+	if len(bs.marks) == 0 {
+		return -1
+	}
+
+	return (bs.start + bs.marks[0].offset) / bitsPerByte
+}
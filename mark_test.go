@@ -0,0 +1,105 @@
+package bitstream
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReader_Mark(t *testing.T) {
+	bs := ReaderFromBytes(0b_1010_0110, 0b_0000_1111, 0b_1100_0011)
+
+	_ = bs.Next(3).Bits()
+
+	m := bs.Mark()
+
+	// a speculative read which fails part way through
+	_, err := bs.Next(30).Bits().AsUInt()
+	assert.True(t, errors.Is(err, io.EOF), "expected EOF, got %v", err)
+
+	if err := bs.Reset(m); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, int64(3), bs.BitOffset(), "unexpected bit offset after reset")
+	assert.Equal(t, 3, bs.BitsRead(), "unexpected number of bits read after reset")
+
+	got, err := bs.Next(5).Bits().AsUInt()
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.Equal(t, uint(0b1_0100), got, "unexpected value after reset")
+
+	assert.True(t, errors.Is(bs.Reset(m), ErrInvalidMark), "a marker can not be reset twice")
+	assert.True(t, errors.Is(bs.Discard(m), ErrInvalidMark), "a reset marker can not be discarded")
+}
+
+func TestReader_MarkStack(t *testing.T) {
+	bs := ReaderFromBytes(1, 2, 3, 4)
+
+	outer := bs.Mark()
+
+	_ = bs.Next(1).Bytes()
+
+	inner := bs.Mark()
+
+	_ = bs.Next(1).Bytes()
+
+	if err := bs.Discard(inner); err != nil {
+		t.Error(err)
+	}
+
+	assert.Equal(t, 2, bs.Position(), "discarding a marker moved the read position")
+
+	// a new marker reuses the index of the discarded one, but is not the same marker
+	replacement := bs.Mark()
+
+	assert.True(t, errors.Is(bs.Reset(inner), ErrInvalidMark), "a discarded marker can not be reset")
+
+	_ = bs.Next(1).Bytes()
+
+	if err := bs.Reset(outer); err != nil {
+		t.Error(err)
+	}
+
+	assert.Equal(t, 0, bs.Position(), "unexpected position after resetting the outer marker")
+	assert.True(t, errors.Is(bs.Reset(replacement), ErrInvalidMark), "markers after a reset one are dropped")
+}
+
+func TestReader_MarkForwardOnly(t *testing.T) {
+	data := make([]byte, readChunkSize*3)
+
+	for idx := range data {
+		data[idx] = byte(idx)
+	}
+
+	bs := NewReader(forwardOnly{bytes.NewReader(data)})
+
+	_ = bs.Next(10).Bytes()
+
+	m := bs.Mark()
+
+	// read past several refills of the buffer
+	_ = bs.Next(readChunkSize * 2).Bytes()
+
+	if err := bs.Reset(m); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := bs.Next(1).Bytes().AsByte()
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.Equal(t, byte(10), got, "unexpected byte after reset")
+
+	// without a marker, the Reader can not move backward
+	_ = bs.OffsetPosition(-1)
+
+	_, err = bs.Next(1).Bytes().AsByte()
+	assert.True(t, errors.Is(err, ErrNotSeekable), "expected ErrNotSeekable, got %v", err)
+}
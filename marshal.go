@@ -0,0 +1,100 @@
+package bitstream
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// BitMarshaler is implemented by types which can write themselves to a Writer.
+// Writer.Write calls MarshalBits for them, before considering any other type.
+type BitMarshaler interface {
+	MarshalBits(w *Writer) error
+}
+
+// writeValue writes a single argument of Write, yielding the number of bits written
+func (w *Writer) writeValue(arg interface{}) (int, error) {
+	switch v := arg.(type) {
+	case BitMarshaler:
+		start := w.BitOffset()
+		err := v.MarshalBits(w)
+
+		return int(w.BitOffset() - start), err
+	case bool:
+		return w.WriteBit(v)
+	case Bits:
+		return w.WriteBits(v)
+	case BitVector:
+		return w.WriteBitVector(v)
+	case []bool:
+		return w.WriteBits(v)
+	case []byte:
+		return w.WriteBytes(v)
+	case string:
+		return w.WriteBytes([]byte(v))
+	case uint8:
+		return w.WriteUint(uint64(v), 8)
+	case uint16:
+		return w.WriteUint(uint64(v), 16)
+	case uint32:
+		return w.WriteUint(uint64(v), 32)
+	case uint64:
+		return w.WriteUint(v, 64)
+	case uint:
+		return w.WriteUint(uint64(v), 64)
+	case int8:
+		return w.WriteInt(int64(v), 8)
+	case int16:
+		return w.WriteInt(int64(v), 16)
+	case int32:
+		return w.WriteInt(int64(v), 32)
+	case int64:
+		return w.WriteInt(v, 64)
+	case int:
+		return w.WriteInt(int64(v), 64)
+	case float32:
+		return w.WriteUint(uint64(math.Float32bits(v)), 32)
+	case float64:
+		return w.WriteUint(math.Float64bits(v), 64)
+	}
+
+	return w.writeReflect(reflect.ValueOf(arg))
+}
+
+// writeReflect writes the values of named types, and slices and arrays, by their kind
+func (w *Writer) writeReflect(v reflect.Value) (bitsWritten int, err error) {
+	switch v.Kind() {
+	case reflect.Bool:
+		return w.WriteBit(v.Bool())
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return w.WriteUint(v.Uint(), int(v.Type().Size())*bitsPerByte)
+	case reflect.Uint:
+		return w.WriteUint(v.Uint(), bitsPerWord)
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return w.WriteInt(v.Int(), int(v.Type().Size())*bitsPerByte)
+	case reflect.Int:
+		return w.WriteInt(v.Int(), bitsPerWord)
+	case reflect.Float32:
+		return w.WriteUint(uint64(math.Float32bits(float32(v.Float()))), 32)
+	case reflect.Float64:
+		return w.WriteUint(math.Float64bits(v.Float()), 64)
+	case reflect.String:
+		return w.WriteBytes([]byte(v.String()))
+	case reflect.Slice, reflect.Array:
+		for idx := 0; idx < v.Len(); idx++ {
+			numWritten, err := w.writeValue(v.Index(idx).Interface())
+
+			bitsWritten += numWritten
+
+			if err != nil {
+				return bitsWritten, fmt.Errorf("element %v: %w", idx, err)
+			}
+		}
+
+		return bitsWritten, nil
+	case reflect.Invalid:
+		return 0, fmt.Errorf("can not write nil: %w", ErrUnsupportedType)
+	}
+
+	return 0, fmt.Errorf("can not write %v: %w", v.Type(), ErrUnsupportedType)
+}
@@ -0,0 +1,163 @@
+package bitstream
+
+import (
+	"fmt"
+	"io"
+	"math/bits"
+)
+
+// IsAligned returns true if the read position, relative to the start of the Reader,
+// is a multiple of nBits. For example, IsAligned(8) is true at the start of a byte.
+func (bs *Reader) IsAligned(nBits int) bool {
+	if nBits <= 1 {
+		return true
+	}
+
+	return bs.bitOffset()%int64(nBits) == 0
+}
+
+// AlignTo skips to the next multiple of nBits, relative to the start of the Reader,
+// yielding the number of bits skipped. It skips nothing if the Reader is already aligned.
+// Errors are as for Skip.
+func (bs *Reader) AlignTo(nBits int) (int, error) {
+	if bs.IsAligned(nBits) {
+		return 0, nil
+	}
+
+	numBits := nBits - int(bs.bitOffset()%int64(nBits))
+
+	return numBits, bs.Skip(numBits)
+}
+
+// Skip moves the read position forward by nBits, without allocating Bits for them.
+// Like a read, it fails with a *ShortReadError at the end of the stream.
+//
+// With strict padding (see SetStrictPadding), the skipped bits must match the padding
+// pattern, otherwise a *PaddingError is returned after skipping them.
+func (bs *Reader) Skip(nBits int) error {
+	if nBits < 0 {
+		return &SeekError{Offset: int64(nBits), Whence: io.SeekCurrent, Err: ErrNegativeOffset}
+	}
+
+	if err := bs.pendingErr(); err != nil {
+		return err
+	}
+
+	offset := bs.bitOffset()
+	mismatch := -1
+
+	// advance a word at a time, so that a forward-only Reader need not buffer every skipped bit
+	for idx := 0; idx < nBits; idx += bitsPerWord {
+		numBits := nBits - idx
+		if numBits > bitsPerWord {
+			numBits = bitsPerWord
+		}
+
+		word, numRead, err := bs.lookahead(bs.bitOffset(), numBits)
+
+		bs.advance(numRead)
+
+		if err != nil {
+			return bs.fail(offset, &ShortReadError{Requested: nBits, Read: idx + numRead, BitOffset: offset, Err: err})
+		}
+
+		if bs.Options.strictPadding && mismatch < 0 {
+			if diff := word ^ patternWord(bs.Options.padding, idx, numBits); diff != 0 {
+				mismatch = idx + bits.TrailingZeros64(diff)
+			}
+		}
+	}
+
+	if mismatch >= 0 {
+		return bs.fail(offset, &PaddingError{BitOffset: offset, Length: nBits, Index: mismatch})
+	}
+
+	return nil
+}
+
+// patternWord packs the n bits of a repeating padding pattern starting at the given index, like readWord
+func patternWord(pattern Bits, idx, n int) uint64 {
+	word := uint64(0)
+
+	if len(pattern) == 0 {
+		return word
+	}
+
+	for bitIdx := 0; bitIdx < n; bitIdx++ {
+		if pattern[(idx+bitIdx)%len(pattern)] {
+			word |= 1 << uint(bitIdx)
+		}
+	}
+
+	return word
+}
+
+// SetStrictPadding enables or disables strict padding. With strict padding, the bits
+// skipped by Skip and AlignTo must be zero, or match the pattern given to SetPadding.
+func (bs *Reader) SetStrictPadding(strict bool) *Reader {
+	bs.Options.strictPadding = strict
+	return bs
+}
+
+// SetPadding sets the pattern that skipped bits must match with strict padding. The pattern
+// repeats from the first skipped bit; an empty pattern means that every bit must be zero.
+func (bs *Reader) SetPadding(pattern Bits) *Reader {
+	bs.Options.padding = append(Bits{}, pattern...)
+	return bs
+}
+
+// IsAligned returns true if the write position is a multiple of nBits from the start of the stream
+func (w *Writer) IsAligned(nBits int) bool {
+	if nBits <= 1 {
+		return true
+	}
+
+	return w.BitOffset()%int64(nBits) == 0
+}
+
+// AlignTo writes padding up to the next multiple of nBits from the start of the stream,
+// yielding the number of padding bits written. It writes nothing if the Writer is already aligned.
+// The padding is zero bits, or the pattern given to SetPadding.
+func (w *Writer) AlignTo(nBits int) (int, error) {
+	if w.IsAligned(nBits) {
+		return 0, nil
+	}
+
+	return w.pad(nBits - int(w.BitOffset()%int64(nBits)))
+}
+
+// PadTo writes padding until the write position is the given bit offset from the start of
+// the stream, yielding the number of padding bits written. An error wrapping ErrOverflow
+// is returned, and nothing is written, if the write position is already past it.
+func (w *Writer) PadTo(bitOffset int64) (int, error) {
+	if position := w.BitOffset(); position > bitOffset {
+		return 0, fmt.Errorf("can not pad to bit offset %v from %v: %w", bitOffset, position, ErrOverflow)
+	}
+
+	return w.pad(int(bitOffset - w.BitOffset()))
+}
+
+// SetPadding sets the pattern written by AlignTo and PadTo. The pattern repeats from the first
+// padding bit; an empty pattern pads with zero bits, and Bits{true} with one bits.
+func (w *Writer) SetPadding(pattern Bits) *Writer {
+	w.padding = append(Bits{}, pattern...)
+	return w
+}
+
+// pad writes nBits bits of the padding pattern
+func (w *Writer) pad(nBits int) (bitsWritten int, err error) {
+	for idx := 0; idx < nBits && err == nil; idx += bitsPerWord {
+		numBits := nBits - idx
+		if numBits > bitsPerWord {
+			numBits = bitsPerWord
+		}
+
+		var numWritten int
+
+		numWritten, err = w.writeWord(patternWord(w.padding, idx, numBits), numBits)
+
+		bitsWritten += numWritten
+	}
+
+	return bitsWritten, err
+}
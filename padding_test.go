@@ -0,0 +1,212 @@
+package bitstream
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReader_AlignTo(t *testing.T) {
+	bs := ReaderFromBytes(0, 0, 0, 0, 0, 0, 0, 0)
+
+	tests := []struct {
+		name        string
+		toRead      int
+		alignment   int
+		wantSkipped int
+		wantOffset  int64
+	}{
+		{"already aligned", 0, 8, 0, 0},
+		{"to byte", 3, 8, 5, 8},
+		{"to word", 1, 32, 23, 32},
+		{"to odd width", 2, 5, 1, 35},
+		{"to single bit", 1, 1, 0, 36},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_ = bs.Next(tt.toRead).Bits()
+
+			skipped, err := bs.AlignTo(tt.alignment)
+			if err != nil {
+				t.Error(err)
+			}
+
+			assert.Equal(t, tt.wantSkipped, skipped, "unexpected number of bits skipped")
+			assert.Equal(t, tt.wantOffset, bs.BitOffset(), "unexpected bit offset")
+			assert.True(t, bs.IsAligned(tt.alignment), "expected to be aligned")
+		})
+	}
+
+	assert.False(t, bs.IsAligned(8), "expected to be unaligned")
+
+	// alignment is relative to the start of a sub-reader
+	sub := ReaderFromBytes(0, 0, 0).Sub(3, 20)
+
+	_ = sub.Next(1).Bits()
+
+	skipped, err := sub.AlignTo(8)
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.Equal(t, 7, skipped, "unexpected number of bits skipped")
+}
+
+func TestReader_Skip(t *testing.T) {
+	bs := ReaderFromBytes(make([]byte, 100)...)
+
+	if err := bs.Skip(3); err != nil {
+		t.Error(err)
+	}
+
+	allocs := testing.AllocsPerRun(10, func() {
+		_ = bs.SetPosition(0).Skip(700)
+	})
+
+	assert.Equal(t, float64(0), allocs, "Skip should not allocate")
+	assert.Equal(t, int64(703), bs.BitOffset(), "unexpected bit offset")
+
+	err := bs.Skip(100)
+
+	var shortRead *ShortReadError
+	if !errors.As(err, &shortRead) {
+		t.Fatalf("expected a *ShortReadError, got %v", err)
+	}
+
+	assert.Equal(t, 97, shortRead.Read, "unexpected number of bits read")
+	assert.True(t, errors.Is(err, io.EOF), "expected EOF")
+	assert.True(t, errors.Is(bs.Skip(-1), ErrNegativeOffset), "expected ErrNegativeOffset")
+}
+
+func TestReader_StrictPadding(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      []byte
+		pattern   Bits
+		toRead    int
+		toSkip    int
+		wantIndex int // -1 for no error
+	}{
+		{"zeros", []byte{0b_0000_0101}, nil, 3, 5, -1},
+		{"not zeros", []byte{0b_0100_0101}, nil, 3, 5, 3},
+		{"not zeros past a word", make([]byte, 10), nil, 0, 78, -1},
+		{"ones", []byte{0b_1111_1000, 0b1}, Bits{T}, 3, 6, -1},
+		{"pattern", []byte{0b_0101_0000, 0b_0000_0101}, Bits{F, T}, 3, 9, -1},
+		{"pattern mismatch", []byte{0b_0101_0000, 0b_0000_0111}, Bits{F, T}, 3, 9, 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bs := ReaderFromBytes(tt.data...).SetStrictPadding(true).SetPadding(tt.pattern)
+
+			_ = bs.Next(tt.toRead).Bits()
+
+			err := bs.Skip(tt.toSkip)
+
+			assert.Equal(t, int64(tt.toRead+tt.toSkip), bs.BitOffset(), "padding should be skipped")
+
+			if tt.wantIndex < 0 {
+				assert.NoError(t, err)
+				return
+			}
+
+			var paddingErr *PaddingError
+			if !errors.As(err, &paddingErr) {
+				t.Fatalf("expected a *PaddingError, got %v", err)
+			}
+
+			assert.Equal(t, int64(tt.toRead), paddingErr.BitOffset, "unexpected bit offset")
+			assert.Equal(t, tt.toSkip, paddingErr.Length, "unexpected length")
+			assert.Equal(t, tt.wantIndex, paddingErr.Index, "unexpected index of the first bad bit")
+		})
+	}
+
+	bs := ReaderFromBytes(0xFF, 0xFF).SetStrictPadding(true).SetSticky(true)
+
+	_, _ = bs.AlignTo(8)
+	_ = bs.Next(1).Bits()
+	_, err := bs.AlignTo(8)
+
+	var paddingErr *PaddingError
+	assert.True(t, errors.As(err, &paddingErr), "expected a *PaddingError, got %v", err)
+	assert.Equal(t, err, bs.Err(), "the padding error should be sticky")
+}
+
+func TestWriter_AlignTo(t *testing.T) {
+	tests := []struct {
+		name          string
+		bigEndian     bool
+		pattern       Bits
+		before        Bits
+		alignment     int
+		wantPadded    int
+		expectedBytes []byte
+	}{
+		{"already aligned", false, nil, Bits{T, T, T, T, T, T, T, T}, 8, 0, []byte{0xFF}},
+		{"zeros", false, nil, Bits{T, T, T}, 8, 5, []byte{0b111}},
+		{"ones", false, Bits{T}, Bits{F, F, F}, 8, 5, []byte{0b_1111_1000}},
+		{"pattern", false, Bits{T, F}, Bits{F, F, F}, 16, 13, []byte{0b_1010_1000, 0b_1010_1010}},
+		{"odd width", false, Bits{T}, Bits{F, F, F}, 5, 2, []byte{0b_0001_1000}},
+		{"big endian, ones", true, Bits{T}, Bits{F, F, F}, 8, 5, []byte{0b_0001_1111}},
+		{"big endian, pattern", true, Bits{T, F}, Bits{F, F, F}, 16, 13, []byte{0b_0001_0101, 0b_0101_0101}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := (&Writer{}).SetPadding(tt.pattern)
+			if tt.bigEndian {
+				w.SetBigEndian()
+			}
+
+			_, _ = w.WriteBits(tt.before)
+
+			padded, err := w.AlignTo(tt.alignment)
+			if err != nil {
+				t.Error(err)
+			}
+
+			assert.Equal(t, tt.wantPadded, padded, "unexpected number of padding bits")
+			assert.Equal(t, tt.expectedBytes, w.Bytes(), "unexpected bytes")
+			assert.True(t, w.IsAligned(tt.alignment), "expected to be aligned")
+
+			// a strict Reader with the same pattern accepts the padding
+			r := ReaderFromBytes(w.Bytes()...).SetStrictPadding(true).SetPadding(tt.pattern)
+			if tt.bigEndian {
+				r.SetBigEndian()
+			}
+
+			_ = r.Next(len(tt.before)).Bits()
+
+			skipped, err := r.AlignTo(tt.alignment)
+
+			assert.NoError(t, err)
+			assert.Equal(t, padded, skipped, "unexpected number of bits skipped")
+		})
+	}
+}
+
+func TestWriter_PadTo(t *testing.T) {
+	w := (&Writer{}).SetPadding(Bits{T})
+
+	_, _ = w.WriteBits(Bits{F, F})
+
+	padded, err := w.PadTo(70)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 68, padded, "unexpected number of padding bits")
+	assert.Equal(t, int64(70), w.BitLen(), "unexpected length")
+	assert.Equal(t, []byte{0xFC, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0b_0011_1111}, w.Bytes(), "unexpected bytes")
+
+	padded, err = w.PadTo(70)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, padded, "expected no padding")
+
+	_, err = w.PadTo(69)
+
+	assert.True(t, errors.Is(err, ErrOverflow), "expected ErrOverflow, got %v", err)
+	assert.Equal(t, int64(70), w.BitLen(), "nothing should be written")
+}
@@ -0,0 +1,136 @@
+package bitstream
+
+import "fmt"
+
+// Placeholder is a run of bits reserved by Writer.Reserve, to be filled in once the value
+// is known, such as a length or checksum which comes before the data it describes.
+type Placeholder struct {
+	w      *Writer
+	offset int64      // the bit offset of the first reserved bit, from the start of the stream
+	nBits  int        // the number of reserved bits
+	order  endianness // the bit order of the Writer when the bits were reserved
+	bytes  endianness // the byte order of the Writer when the bits were reserved, for FillUint
+	filled bool
+}
+
+// Reserve writes nBits zero bits at the write position, yielding a Placeholder which can overwrite them later
+// with Fill or FillUint, regardless of what has been written since.
+//
+// A Writer created by NewWriterTo does not flush the reserved bits, or any after them,
+// until the Placeholder is filled.
+func (w *Writer) Reserve(nBits int) (*Placeholder, error) {
+	if nBits < 0 {
+		return nil, fmt.Errorf("can not reserve %v bits: %w", nBits, ErrInvalidWidth)
+	}
+
+	p := &Placeholder{
+		w:      w,
+		offset: w.BitOffset(),
+		nBits:  nBits,
+		order:  w.endianness,
+		bytes:  w.byteOrder,
+	}
+
+	// registered first, so that the reserved bits are never flushed
+	w.reserved = append(w.reserved, p)
+
+	for idx := 0; idx < nBits; idx += bitsPerWord {
+		numBits := nBits - idx
+		if numBits > bitsPerWord {
+			numBits = bitsPerWord
+		}
+
+		if _, err := w.writeWord(0, numBits); err != nil {
+			return nil, err
+		}
+	}
+
+	return p, nil
+}
+
+// BitOffset returns the offset of the first reserved bit, from the start of the stream
+func (p *Placeholder) BitOffset() int64 {
+	return p.offset
+}
+
+// Len returns the number of reserved bits
+func (p *Placeholder) Len() int {
+	return p.nBits
+}
+
+// Fill overwrites the reserved bits with the given Bits, which must be exactly as many.
+// A Placeholder can only be filled once.
+func (p *Placeholder) Fill(b Bits) error {
+	if p.filled {
+		return ErrPlaceholderFilled
+	}
+
+	if len(b) != p.nBits {
+		return fmt.Errorf("can not fill %v reserved bits with %v bits: %w", p.nBits, len(b), ErrInvalidWidth)
+	}
+
+	for idx := range b {
+		p.w.setBit(p.offset+int64(idx), b[idx], p.order)
+	}
+
+	p.release()
+
+	return nil
+}
+
+// FillUint overwrites the reserved bits with v, as WriteUint would have written it.
+// An error wrapping ErrOverflow is returned if v does not fit, and ErrInvalidWidth
+// if more than 64 bits were reserved. A Placeholder can only be filled once.
+func (p *Placeholder) FillUint(v uint64) error {
+	if p.filled {
+		return ErrPlaceholderFilled
+	}
+
+	if p.nBits > bitsPerWord {
+		return fmt.Errorf("can not fill %v reserved bits with an integer: %w", p.nBits, ErrInvalidWidth)
+	}
+
+	if p.nBits < bitsPerWord && v>>uint(p.nBits) != 0 {
+		return fmt.Errorf("can not fill %v reserved bits with %v: %w", p.nBits, v, ErrOverflow)
+	}
+
+	v = orderBytes(v, p.nBits, p.bytes)
+
+	for idx := 0; idx < p.nBits; idx++ {
+		p.w.setBit(p.offset+int64(idx), (v>>uint(idx))&1 == 1, p.order)
+	}
+
+	p.release()
+
+	return nil
+}
+
+// release marks the Placeholder as filled, so that the Writer can flush its bits
+func (p *Placeholder) release() {
+	p.filled = true
+
+	reserved := p.w.reserved
+
+	for idx := range reserved {
+		if reserved[idx] == p {
+			p.w.reserved = append(reserved[:idx], reserved[idx+1:]...)
+			break
+		}
+	}
+}
+
+// retire removes the placeholders which match, marking them as filled so that they can not
+// overwrite bits which are no longer theirs
+func (w *Writer) retire(match func(p *Placeholder) bool) {
+	reserved := w.reserved[:0]
+
+	for _, p := range w.reserved {
+		if match(p) {
+			p.filled = true
+		} else {
+			reserved = append(reserved, p)
+		}
+	}
+
+	w.reserved = reserved
+}
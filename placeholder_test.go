@@ -0,0 +1,167 @@
+package bitstream
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestWriter_Reserve(t *testing.T) {
+	tests := []struct {
+		name          string
+		bigEndian     bool
+		before        Bits
+		nBits         int
+		value         uint64
+		expectedBytes []byte
+	}{
+		{"aligned", false, Bits{}, 16, 0xABCD, []byte{0xCD, 0xAB, 0xFF}},
+		{"unaligned", false, Bits{T, F, T}, 12, 0xABC, []byte{0b_1110_0101, 0b_1101_0101, 0b_0111_1111}},
+		{"big endian, unaligned", true, Bits{T, F, T}, 4, 0b_1011, []byte{0b_1011_1011, 0b_1111_1110}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &Writer{}
+			if tt.bigEndian {
+				w.SetBigEndian()
+			}
+
+			_, _ = w.WriteBits(tt.before)
+
+			p, err := w.Reserve(tt.nBits)
+			if err != nil {
+				t.Fatalf("Reserve() error = %v", err)
+			}
+
+			if p.BitOffset() != int64(len(tt.before)) || p.Len() != tt.nBits {
+				t.Errorf("Reserve() = offset %v, length %v, want %v, %v", p.BitOffset(), p.Len(), len(tt.before), tt.nBits)
+			}
+
+			// data written after the placeholder is left alone
+			_, _ = w.WriteByte(0xFF)
+
+			if err := p.FillUint(tt.value); err != nil {
+				t.Fatalf("FillUint() error = %v", err)
+			}
+
+			if got := w.Bytes(); !reflect.DeepEqual(got, tt.expectedBytes) {
+				t.Errorf("Bytes() = %08b, want %08b", got, tt.expectedBytes)
+			}
+
+			r := NewReader().FromBytes(w.Bytes()...)
+			if tt.bigEndian {
+				r.SetBigEndian()
+			}
+
+			_ = r.Next(len(tt.before)).Bits()
+
+			if got, _ := r.Next(tt.nBits).Bits().AsUInt64(); got != tt.value {
+				t.Errorf("read back %v, want %v", got, tt.value)
+			}
+		})
+	}
+}
+
+func TestPlaceholder_Fill(t *testing.T) {
+	w := &Writer{}
+
+	_, _ = w.WriteBit(T)
+
+	p, _ := w.Reserve(3)
+
+	if err := p.Fill(Bits{T, T}); !errors.Is(err, ErrInvalidWidth) {
+		t.Errorf("Fill() error = %v, want %v", err, ErrInvalidWidth)
+	}
+
+	if err := p.Fill(Bits{F, T, T}); err != nil {
+		t.Errorf("Fill() error = %v", err)
+	}
+
+	if err := p.Fill(Bits{F, T, T}); !errors.Is(err, ErrPlaceholderFilled) {
+		t.Errorf("Fill() error = %v, want %v", err, ErrPlaceholderFilled)
+	}
+
+	if got := w.Bytes(); !reflect.DeepEqual(got, []byte{0b_1101}) {
+		t.Errorf("Bytes() = %v, want %v", got, []byte{0b_1101})
+	}
+
+	p, _ = w.Reserve(4)
+
+	if err := p.FillUint(16); !errors.Is(err, ErrOverflow) {
+		t.Errorf("FillUint() error = %v, want %v", err, ErrOverflow)
+	}
+
+	if _, err := w.Reserve(-1); !errors.Is(err, ErrInvalidWidth) {
+		t.Errorf("Reserve() error = %v, want %v", err, ErrInvalidWidth)
+	}
+
+	if err := w.Close(); !errors.Is(err, ErrUnfilledPlaceholder) {
+		t.Errorf("Close() error = %v, want %v", err, ErrUnfilledPlaceholder)
+	}
+
+	if err := p.FillUint(15); err != nil {
+		t.Errorf("FillUint() error = %v", err)
+	}
+
+	if err := w.Close(); err != nil {
+		t.Errorf("Close() error = %v", err)
+	}
+}
+
+func TestWriter_ReserveStreaming(t *testing.T) {
+	data := make([]byte, writeChunkSize*2)
+	for idx := range data {
+		data[idx] = byte(idx)
+	}
+
+	sink := &bytes.Buffer{}
+	w := NewWriterTo(sink)
+
+	_, _ = w.WriteBytes(data[:10])
+	_, _ = w.WriteBit(T)
+
+	size, _ := w.Reserve(32)
+	checksum, _ := w.Reserve(8)
+
+	_, _ = w.WriteBytes(data)
+
+	if err := w.Flush(true); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	if sink.Len() != 10 {
+		t.Errorf("flushed %v bytes, want only the 10 before the placeholder", sink.Len())
+	}
+
+	if err := size.FillUint(uint64(len(data))); err != nil {
+		t.Fatalf("FillUint() error = %v", err)
+	}
+
+	if err := w.Flush(false); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	if sink.Len() != 14 {
+		t.Errorf("flushed %v bytes, want the 14 before the second placeholder", sink.Len())
+	}
+
+	if err := checksum.FillUint(0xAA); err != nil {
+		t.Fatalf("FillUint() error = %v", err)
+	}
+
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	expected := &Writer{}
+	_, _ = expected.WriteBytes(data[:10])
+	_, _ = expected.WriteBit(T)
+	_, _ = expected.WriteUint(uint64(len(data)), 32)
+	_, _ = expected.WriteUint(0xAA, 8)
+	_, _ = expected.WriteBytes(data)
+
+	if !bytes.Equal(sink.Bytes(), expected.Bytes()) {
+		t.Errorf("streamed bytes differ from in-memory bytes")
+	}
+}
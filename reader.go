@@ -1,25 +1,50 @@
 // Package bitstream provides a stream reader and writer implementation that can
 // read/write data that is not byte aligned.
+//
+// The package holds no shared mutable state. A single Reader or Writer is not safe
+// for concurrent use, but separate instances can be used from separate goroutines,
+// including Readers created from the same byte slice. Bits and Response values are
+// never modified by this package, so their methods are safe to call concurrently.
 package bitstream
 
 import (
 	"bytes"
+	"encoding/binary"
+	"errors"
 	"fmt"
 	"io"
+	"math/bits"
 )
 
 const (
-	bitsPerByte = 8
-	bitMask     = 0x01
+	bitsPerByte  = 8
+	bitsPerWord  = 64
+	bytesPerWord = bitsPerWord / bitsPerByte
+
+	// readChunkSize is the number of bytes buffered from a stream source at a time
+	readChunkSize = 4096
 )
 
-// NewReader creates a new Reader using the given io.ReadSeeker
+// NewReader creates a new Reader using the given io.ReadSeeker or io.Reader.
+//
+// When the source can not seek (like a net.Conn, gzip.Reader or a pipe), the Reader
+// is forward-only: it buffers just enough of the source to read the next bits, and
+// any attempt to move backward yields ErrNotSeekable.
 func NewReader(args ...interface{}) *Reader {
-	bs := &Reader{}
+	bs := &Reader{size: -1, limit: -1}
+
+	if len(args) == 0 {
+		return bs
+	}
+
+	if r, good := args[0].(io.Reader); good {
+		bs.stream = r
+	}
 
-	if len(args) > 0 {
-		if rs, good := args[0].(io.ReadSeeker); good {
-			bs.stream = rs
+	// an *os.File is always an io.ReadSeeker, but seeking fails on pipes and terminals
+	if rs, good := args[0].(io.ReadSeeker); good {
+		if position, err := rs.Seek(0, io.SeekCurrent); err == nil {
+			bs.seeker, bs.position, bs.bufOffset = rs, position, position
 		}
 	}
 
@@ -39,59 +64,134 @@ func Copy(src *Reader) *Reader {
 // Reader is used for reading structured data that is not byte aligned.
 // It can read one or many bits and yield a BitInterpreter.
 // The BitInterpreter can then be used to interpret the bits as another type.
+//
+// A Reader is not safe for concurrent use; every method, including Position and
+// Length, may mutate its read buffer. Readers over the same byte slice can be used
+// concurrently, but Readers sharing an io.ReadSeeker cannot, as each one seeks the stream.
 type Reader struct {
-	stream      io.ReadSeeker
-	bitPosition int // the bit index within the current byte, 0 to 7
-	bitsRead    int // the number of bits read since the last seek
+	stream      io.Reader
+	seeker      io.Seeker // the stream as an io.Seeker, nil if the stream is forward-only
+	buf         []byte    // bytes buffered from the stream, starting at bufOffset
+	bufOffset   int64     // the byte offset of buf[0] within the stream
+	scratch     []byte    // backing storage for buf when reading from a stream, owned by this Reader
+	size        int64     // the length of the stream in bytes, or -1 if not yet known
+	start       int64     // the bit offset within the stream that positions are relative to
+	limit       int64     // the number of bits that can be read after start, or -1 for no limit
+	position    int64     // the byte index within the stream
+	bitPosition int       // the bit index within the current byte, 0 to 7
+	bitsRead    int       // the number of bits read since the last seek
 	unitsToRead int
+	err         error  // an error from a method which can not return one, yielded by the next read
+	stickyErr   error  // the first error of a sticky Reader
+	marks       []mark // the saved states of the Reader, see Mark
+	markSerial  int    // the serial number of the last mark
 	Options     options
 }
 
 type endianness int
 
-// endianess types
+// endianess types, for both the bit order and the byte order
 const (
 	LittleEndian endianness = iota
 	BigEndian
 )
 
 type options struct {
-	endianness // determines which end the bits are read from the byte (from biggest end or smallest end)
+	endianness               // determines which end the bits are read from the byte (from biggest end or smallest end)
+	byteOrder     endianness // determines the order of the bytes read by Bytes, see SetByteOrder
+	sticky        bool       // determines if the first error makes every later read a no-op
+	strictPadding bool       // determines if skipped bits must match the padding pattern
+	padding       Bits       // the repeating pattern of padding bits, zeros if empty
 }
 
 // ReaderFromBytes yields a new Reader, using the given bytes as the stream source
 func (bs *Reader) FromBytes(b ...byte) *Reader {
-	bs.stream = bytes.NewReader(b)
+	stream := bytes.NewReader(b)
+	bs.stream, bs.seeker = stream, stream
+
+	// the whole stream is already in memory, so it never needs to be refilled
+	bs.buf, bs.bufOffset, bs.size = b, 0, int64(len(b))
+
 	return bs
 }
 
 // Copy creates a copy of this bitstream, including the data.
-// We do some funky stuff here regarding byte and bit offset to mimic the
-// original implementation.
+// The copy starts at the same byte and bit position, with no bits read.
+//
+// A forward-only Reader can not be copied, reading from the copy yields ErrNotSeekable.
 func (bs *Reader) Copy() *Reader {
-	currentPosition, _ := bs.Seek(0, io.SeekCurrent)
-	currentBitPosition := bs.bitPosition
-	absoluteBitPosition := (int(currentPosition) * bitsPerByte) + currentBitPosition
+	if bs.stream != nil && bs.seeker == nil {
+		dst := ReaderFromBytes()
+		dst.err = fmt.Errorf("error copying Bitstream: %w", ErrNotSeekable)
 
-	length, _ := bs.Seek(0, io.SeekEnd)
+		return dst
+	}
 
-	_, _ = bs.Seek(0, io.SeekStart)
-	buf := make([]byte, length)
+	// only the bytes within the window of a sub-reader are copied
+	first, end := bs.start/bitsPerByte, int64(bs.Length())
+	if bs.limit >= 0 {
+		end = (bs.start + bs.limit + bitsPerByte - 1) / bitsPerByte
+	}
+
+	buf := make([]byte, end-first)
 
 	if bs.stream != nil {
-		_, _ = bs.stream.Read(buf)
+		data, _ := bs.bytesAt(first, len(buf))
+		copy(buf, data)
 	}
 
 	dst := ReaderFromBytes(buf...)
 
-	dst.SetPosition(0).OffsetBitPosition(absoluteBitPosition)
-
-	bs.SetPosition(int(currentPosition))
-	bs.SetBitPosition(int(currentBitPosition))
+	dst.start, dst.limit = bs.start%bitsPerByte, bs.limit
+	dst.position, dst.bitPosition = bs.position, bs.bitPosition
 
 	return dst
 }
 
+// Sub creates a Reader over the bitLength bits at bitOffset, relative to the start of this
+// Reader. The sub-reader has its own read position, starting at zero, and a read past the end
+// of its window yields a *ShortReadError wrapping io.EOF. A window extending past the end of
+// this Reader's window or of the stream is truncated, and a negative offset or length yields
+// ErrNegativeOffset.
+//
+// The underlying data is shared rather than copied, so a sub-reader over an io.ReadSeeker
+// can not be used concurrently with this Reader. A forward-only Reader can not have
+// sub-readers, reading from one yields ErrNotSeekable.
+func (bs *Reader) Sub(bitOffset, bitLength int64) *Reader {
+	sub := &Reader{
+		stream:  bs.stream,
+		seeker:  bs.seeker,
+		size:    bs.size,
+		start:   bs.start + bitOffset,
+		limit:   bitLength,
+		Options: bs.Options,
+	}
+
+	// a buffer in scratch will be overwritten by this Reader, so only in-memory data is shared
+	if bs.scratch == nil {
+		sub.buf, sub.bufOffset = bs.buf, bs.bufOffset
+	}
+
+	// the window is truncated to the bits this Reader has, whenever their number is known
+	if length, err := bs.BitLength(); err == nil && bitOffset+bitLength > length {
+		sub.limit = length - bitOffset
+	}
+
+	if sub.limit < 0 {
+		sub.limit = 0
+	}
+
+	switch {
+	case bs.stream != nil && bs.seeker == nil:
+		sub.err = fmt.Errorf("error creating sub-reader: %w", ErrNotSeekable)
+	case bitOffset < 0 || bitLength < 0:
+		sub.err = fmt.Errorf("error creating sub-reader: %w", ErrNegativeOffset)
+		sub.start, sub.limit = bs.start, 0
+	}
+
+	return sub
+}
+
 // BitsRead returns a number of readed bits
 func (bs *Reader) BitsRead() int {
 	return bs.bitsRead
@@ -103,100 +203,471 @@ func (bs *Reader) SetBitsRead(n int) *Reader {
 	return bs
 }
 
-var tmpBit = make([]byte, 1)
-
 // readBit reads a single bit from the stream source. It will always yield a boolean,
-// even if the stream is empty or at the end of the file. However, if Reader.Options.ReadBeyondEOF
-// is false, readBit will return an io.EOF error during this read.
+// even if the stream is empty or at the end of the file, in which case an io.EOF
+// error is also returned.
 //
 // It is also important to note that this read operation mutates
 // the Reader BytePosition and BitPosition.
 func (bs *Reader) readBit() (bool, error) {
+	word, _, err := bs.readWord(1)
+
+	return word > 0, err
+}
+
+// readBits will read n bits into a BitInterpreter. If the end of the stream is
+// encountered, the bits that could not be read are left as false and the error is returned.
+func (bs *Reader) readBits(n int) (Bits, error) {
+	if err := bs.pendingErr(); err != nil {
+		return make(Bits, n), err
+	}
+
+	bits, numRead, err := bs.peekBits(n)
+	if err != nil {
+		err = bs.fail(bs.bitOffset(), err)
+	}
+
+	bs.advance(numRead)
+
+	return bits, err
+}
+
+// peekBits reads n bits into a BitInterpreter without moving the read position,
+// also yielding the number of bits that could be read. See readBits.
+func (bs *Reader) peekBits(n int) (Bits, int, error) {
+	bits := make(Bits, n) // preallocate
+	offset := bs.bitOffset()
+
+	// read a word at a time, then unpack each bit
+	for idx := 0; idx < n; idx += bitsPerWord {
+		numBits := n - idx
+		if numBits > bitsPerWord {
+			numBits = bitsPerWord
+		}
+
+		word, numRead, err := bs.lookahead(offset+int64(idx), numBits)
+
+		for bitIdx := 0; bitIdx < numRead; bitIdx++ {
+			bits[idx+bitIdx] = (word>>uint(bitIdx))&1 > 0
+		}
+
+		if err != nil {
+			return bits, idx + numRead, &ShortReadError{Requested: n, Read: idx + numRead, BitOffset: offset, Err: err}
+		}
+	}
+
+	return bits, n, nil
+}
+
+// readWord reads up to 64 bits, packing them into a word with the first bit read as the
+// least-significant bit. It yields the number of bits actually read, which is only less
+// than n when an error (such as io.EOF) is also returned.
+func (bs *Reader) readWord(n int) (word uint64, numRead int, err error) {
+	if err := bs.pendingErr(); err != nil {
+		return 0, 0, err
+	}
+
+	word, numRead, err = bs.lookahead(bs.bitOffset(), n)
+	if err != nil {
+		offset := bs.bitOffset()
+		err = bs.fail(offset, &ShortReadError{Requested: n, Read: numRead, BitOffset: offset, Err: err})
+	}
+
+	bs.advance(numRead)
+
+	return word, numRead, err
+}
+
+// pendingErr yields the error that a read fails with before reading anything: the first
+// error of a sticky Reader, or an error from a method which could not return it.
+func (bs *Reader) pendingErr() error {
+	if bs.Options.sticky && bs.stickyErr != nil {
+		return bs.stickyErr
+	}
+
+	if bs.err != nil {
+		err := bs.err
+		bs.err = nil
+
+		return bs.fail(bs.bitOffset(), err)
+	}
+
+	return nil
+}
+
+// fail handles an error from a read which starts at the given bit offset.
+// A sticky Reader keeps the first error, with the bit offset of the read.
+func (bs *Reader) fail(offset int64, err error) error {
+	if !bs.Options.sticky {
+		return err
+	}
+
+	if bs.stickyErr == nil {
+		bs.stickyErr = &ReadError{BitOffset: offset, Err: err}
+	}
+
+	return bs.stickyErr
+}
+
+// advance moves the read position forward past n bits which have been read
+func (bs *Reader) advance(n int) {
+	bs.bitsRead += n
+	bs.setBitOffset(bs.bitOffset() + int64(n))
+}
+
+// lookahead reads up to 64 bits at the given bit offset relative to the start of
+// the Reader, like peekWord, but also handles a nil stream and yields io.EOF at the
+// limit of a sub-reader.
+func (bs *Reader) lookahead(offset int64, n int) (word uint64, numRead int, err error) {
 	if bs.stream == nil {
-		return false, io.EOF
+		return 0, 0, io.EOF
 	}
 
-	bp := uint8(bs.bitPosition) // we store a copy, it gets altered during the read
+	var errLimit error
+
+	if bs.limit >= 0 && offset+int64(n) > bs.limit {
+		n, errLimit = int(bs.limit-offset), io.EOF
+	}
 
-	if numRead, err := bs.stream.Read(tmpBit); numRead < 1 || err != nil {
-		return false, fmt.Errorf("error reading bits: %w", err)
+	word, numRead, err = bs.peekWord(bs.start+offset, n)
+	if err == nil {
+		err = errLimit
 	}
 
-	bs.bitsRead++
-	bs.OffsetBitPosition(+1)
+	return word, numRead, err
+}
 
-	// we only seek to the next byte in the stream when we
-	// read the last bit (index 7) of the current byte
-	if bp < bitsPerByte {
-		// otherwise, we seek backward by one byte so that
-		// the next read yields the same byte
-		_, _ = bs.Seek(-1, io.SeekCurrent)
+// peekWord reads up to 64 bits starting at the given absolute bit offset, without
+// moving the read position. See readWord.
+func (bs *Reader) peekWord(offset int64, n int) (word uint64, numRead int, err error) {
+	if n <= 0 {
+		return 0, 0, nil
 	}
 
-	shift := uint8(0)
+	shift := uint(offset % bitsPerByte)
 
-	switch bs.Options.endianness {
-	case LittleEndian:
-		shift = bp
-	case BigEndian:
-		shift = uint8(bitsPerByte) - bp
+	// fast path, the bits are within a single word of buffered data
+	if idx := offset/bitsPerByte - bs.bufOffset; bs.Options.endianness == LittleEndian &&
+		idx >= 0 && idx+bytesPerWord <= int64(len(bs.buf)) && int(shift)+n <= bitsPerWord {
+		word = binary.LittleEndian.Uint64(bs.buf[idx:]) >> shift
+
+		if n < bitsPerWord {
+			word &= (1 << uint(n)) - 1
+		}
+
+		return word, n, nil
+	}
+	numBytes := (int(shift) + n + bitsPerByte - 1) / bitsPerByte // at most 9
+
+	data, err := bs.bytesAt(offset/bitsPerByte, numBytes)
+
+	numRead = len(data)*bitsPerByte - int(shift)
+	if numRead > n {
+		numRead = n
+	} else if numRead < 0 {
+		numRead = 0
 	}
 
-	return ((tmpBit[0] >> shift) & bitMask) > 0, nil
+	if numRead == 0 {
+		return 0, 0, err
+	}
+
+	word = bs.loadWord(data) >> shift
+
+	// the 9th byte only exists when the bits straddle a word boundary
+	if len(data) > bytesPerWord {
+		word |= uint64(bs.orderByte(data[bytesPerWord])) << (bitsPerWord - shift)
+	}
+
+	if numRead < bitsPerWord {
+		word &= (1 << uint(numRead)) - 1
+	}
+
+	return word, numRead, err
 }
 
-// readBits will read n bits into a BitInterpreter. If the Reader.Options.ReadBeyondEOF
-// option is false, the resultant BitInterpreter will be truncated to only contain bits
-// that were successfully read before encountering the end of file.
-func (bs *Reader) readBits(n int) (Bits, error) {
-	bits := make(Bits, n) // preallocate
+// loadWord packs up to the first 8 bytes of data into a little-endian word,
+// with the bits of each byte arranged in the order they are read from the stream.
+func (bs *Reader) loadWord(data []byte) uint64 {
+	if len(data) >= bytesPerWord && bs.Options.endianness == LittleEndian {
+		return binary.LittleEndian.Uint64(data)
+	}
+
+	word := uint64(0)
+
+	for idx := 0; idx < len(data) && idx < bytesPerWord; idx++ {
+		word |= uint64(bs.orderByte(data[idx])) << uint(idx*bitsPerByte)
+	}
+
+	return word
+}
+
+// orderByte arranges the bits of a byte so that the bit which is read first
+// is the least significant bit.
+func (bs *Reader) orderByte(b byte) byte {
+	if bs.Options.endianness == BigEndian {
+		return bits.Reverse8(b)
+	}
+
+	return b
+}
+
+// bytesAt yields up to n bytes of the stream, starting at the given byte offset.
+// Fewer than n bytes are returned only when an error (such as io.EOF) is also returned.
+func (bs *Reader) bytesAt(offset int64, n int) ([]byte, error) {
+	bufStart := offset - bs.bufOffset
+	bufEnd := bufStart + int64(n)
+
+	if bufStart >= 0 && bufEnd <= int64(len(bs.buf)) {
+		return bs.buf[bufStart:bufEnd], nil
+	}
+
+	// a forward-only stream has already discarded these bytes
+	if bufStart < 0 && bs.seeker == nil {
+		return nil, ErrNotSeekable
+	}
+
+	if bs.size >= 0 && offset+int64(n) > bs.size {
+		if offset >= bs.size {
+			return nil, io.EOF
+		}
+
+		data, _ := bs.bytesAt(offset, int(bs.size-offset))
+
+		return data, io.EOF
+	}
+
+	if err := bs.fill(offset, n); err != nil {
+		return nil, err
+	}
+
+	return bs.bytesAt(offset, n)
+}
+
+// fill refills the buffer from the stream, starting at the given byte offset. If the end
+// of the stream is reached, the size of the stream becomes known.
+func (bs *Reader) fill(offset int64, n int) error {
+	if bs.seeker == nil {
+		return bs.fillForward(offset, n)
+	}
+
+	if n < readChunkSize {
+		n = readChunkSize
+	}
+
+	if cap(bs.scratch) < n {
+		bs.scratch = make([]byte, n)
+	}
+
+	if _, err := bs.seeker.Seek(offset, io.SeekStart); err != nil {
+		return &SeekError{Offset: offset, Whence: io.SeekStart, Err: err}
+	}
+
+	numRead, err := io.ReadFull(bs.stream, bs.scratch[:n])
+
+	bs.buf, bs.bufOffset = bs.scratch[:numRead], offset
+
+	// a read starting past the end of the stream does not tell where the end is
+	if numRead == 0 && err == io.EOF { // nolint:errorlint // io.ReadFull yields io.EOF unwrapped
+		size, seekErr := bs.seeker.Seek(0, io.SeekEnd)
+		if seekErr != nil {
+			return &SeekError{Offset: 0, Whence: io.SeekEnd, Err: seekErr}
+		}
+
+		bs.size = size
+
+		return nil
+	}
+
+	return bs.filled(offset+int64(numRead), err)
+}
+
+// fillForward appends to the buffer from a forward-only stream until it holds the n bytes at
+// the given byte offset. Buffered bytes before the read position are discarded, as are any
+// bytes of the stream which are skipped over.
+func (bs *Reader) fillForward(offset int64, n int) error {
+	keep := (bs.start + bs.bitOffset()) / bitsPerByte
+	if offset < keep {
+		keep = offset
+	}
+
+	// the bytes after the oldest mark are kept, so that the Reader can be reset to it
+	if marked := bs.markedByte(); marked >= 0 && marked < keep {
+		keep = marked
+	}
+
+	bufEnd := bs.bufOffset + int64(len(bs.buf))
+
+	if keep >= bufEnd {
+		skipped, err := io.CopyN(io.Discard, bs.stream, keep-bufEnd)
+
+		bs.buf, bs.bufOffset = bs.buf[:0], bufEnd+skipped
 
-	// read each bit, one by one
-	for idx := 0; idx < n; idx++ {
-		b, err := bs.readBit()
-		// if there is an error (EOF), we truncate if ReadBeyondEOF is false
 		if err != nil {
-			return bits, err
+			return bs.filled(bs.bufOffset, err)
 		}
+	} else if keep > bs.bufOffset {
+		bs.buf = bs.buf[keep-bs.bufOffset:]
+		bs.bufOffset = keep
+	}
+
+	need := int(offset+int64(n)-bs.bufOffset) - len(bs.buf)
 
-		bits[idx] = b
+	if length := len(bs.buf) + need; cap(bs.scratch) < length || cap(bs.scratch) < readChunkSize {
+		if length < readChunkSize {
+			length = readChunkSize
+		}
+
+		bs.scratch = make([]byte, length)
 	}
 
-	return bits, nil
+	// move the kept bytes to the start of the scratch buffer, then read as much as fits after them
+	bs.buf = bs.scratch[:copy(bs.scratch, bs.buf)]
+
+	numRead, err := io.ReadAtLeast(bs.stream, bs.scratch[len(bs.buf):cap(bs.scratch)], need)
+
+	bs.buf = bs.scratch[:len(bs.buf)+numRead]
+
+	return bs.filled(bs.bufOffset+int64(len(bs.buf)), err)
+}
+
+// filled handles the error from filling the buffer, where end is the byte offset
+// of the end of the buffered data.
+func (bs *Reader) filled(end int64, err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case err == io.EOF, err == io.ErrUnexpectedEOF: // nolint:errorlint // io.ReadFull yields these unwrapped
+		bs.size = end
+		return nil
+	default:
+		return err
+	}
+}
+
+// bitOffset returns the absolute bit offset within the stream
+func (bs *Reader) bitOffset() int64 {
+	return bs.position*bitsPerByte + int64(bs.bitPosition)
 }
 
-// Seek sets the byte position within the stream.
+// setBitOffset sets the absolute bit offset within the stream
+func (bs *Reader) setBitOffset(offset int64) {
+	bs.position, bs.bitPosition = offset/bitsPerByte, int(offset%bitsPerByte)
+}
+
+// seekBitOffset sets the absolute bit offset within the stream, failing with
+// ErrNotSeekable if a forward-only stream would move backward.
+func (bs *Reader) seekBitOffset(offset int64) error {
+	if bs.stream != nil && bs.seeker == nil && offset < bs.bitOffset() {
+		return ErrNotSeekable
+	}
+
+	bs.setBitOffset(offset)
+
+	return nil
+}
+
+// Seek sets the byte position within the stream. The bit position within the byte is unchanged.
+// If the position can not be set, the error is a *SeekError.
 func (bs *Reader) Seek(offset int64, whence int) (int64, error) {
 	if bs.stream == nil {
 		return 0, io.EOF
 	}
 
-	result, err := bs.stream.Seek(offset, whence)
+	position, err := bs.seekTarget(offset, whence, bs.position, bitsPerByte)
 	if err != nil {
-		return 0, fmt.Errorf("error seeking Bitstream: %w", err)
+		return 0, &SeekError{Offset: offset, Whence: whence, Err: err}
 	}
 
-	return result, nil
+	if err := bs.seekBitOffset(position*bitsPerByte + int64(bs.bitPosition)); err != nil {
+		return 0, &SeekError{Offset: offset, Whence: whence, Err: err}
+	}
+
+	return position, nil
+}
+
+// BitOffset returns the absolute bit offset within the stream, which is
+// the byte position multiplied by 8, plus the bit position.
+func (bs *Reader) BitOffset() int64 {
+	return bs.bitOffset()
+}
+
+// SeekBit sets the absolute bit offset within the stream, interpreting whence as io.Seeker does.
+// It returns the new bit offset, and resets the number of bits read.
+//
+// Seeking before the start of the stream is an error, as is moving a forward-only Reader backward.
+// The error is a *SeekError, wrapping ErrNegativeOffset, ErrInvalidWhence or ErrNotSeekable.
+func (bs *Reader) SeekBit(offset int64, whence int) (int64, error) {
+	if bs.stream == nil {
+		return 0, io.EOF
+	}
+
+	target, err := bs.seekTarget(offset, whence, bs.bitOffset(), 1)
+	if err != nil {
+		return 0, &SeekError{Offset: offset, Whence: whence, Err: err}
+	}
+
+	if err := bs.seekBitOffset(target); err != nil {
+		return 0, &SeekError{Offset: offset, Whence: whence, Err: err}
+	}
+
+	bs.bitsRead = 0
+
+	return target, nil
+}
+
+// seekTarget resolves an offset relative to whence into an absolute offset, measured in
+// units of the given number of bits. current is the current offset, in the same units.
+func (bs *Reader) seekTarget(offset int64, whence int, current, bitsPerUnit int64) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += current
+	case io.SeekEnd:
+		length, err := bs.BitLength()
+		if err != nil {
+			return 0, err
+		}
+
+		offset += (length + bitsPerUnit - 1) / bitsPerUnit
+	default:
+		return 0, ErrInvalidWhence
+	}
+
+	if offset < 0 {
+		return 0, ErrNegativeOffset
+	}
+
+	return offset, nil
 }
 
 // Position returns the byte-position within the stream
 func (bs *Reader) Position() int {
-	p, _ := bs.Seek(0, io.SeekCurrent)
-	return int(p)
+	return int(bs.position)
 }
 
 // SetPosition sets the byte position within the stream.
 // The final position will be a positive integer.
+//
+// Moving a forward-only Reader backward fails, and the next read yields ErrNotSeekable.
 func (bs *Reader) SetPosition(i int) *Reader {
-	_, _ = bs.Seek(int64(i), io.SeekStart)
+	if _, err := bs.Seek(int64(i), io.SeekStart); errors.Is(err, ErrNotSeekable) {
+		bs.err = err
+	}
 
 	return bs
 }
 
 // OffsetPosition will offset the current position by the given integer.
 // The final position will be a positive integer.
+//
+// Moving a forward-only Reader backward fails, and the next read yields ErrNotSeekable.
 func (bs *Reader) OffsetPosition(i int) int {
-	position, _ := bs.Seek(int64(i), io.SeekCurrent)
+	position, err := bs.Seek(int64(i), io.SeekCurrent)
+	if errors.Is(err, ErrNotSeekable) {
+		bs.err = err
+	}
+
 	return int(position)
 }
 
@@ -212,27 +683,20 @@ func (bs *Reader) BitPosition() int {
 // bit position 0 of the current byte!
 //
 // Example: setting to -1 is the same as calling OffsetPosition(-1) and then SetBitPosition(7)
+//
+// Moving a forward-only Reader backward fails, and the next read yields ErrNotSeekable.
 func (bs *Reader) SetBitPosition(i int) *Reader {
-	position := bs.Position()
+	offset := bs.position*bitsPerByte + int64(i)
 
 	// corner case, can't go back any further
-	if i < 0 && position <= 0 {
-		i = 0
+	if offset < 0 {
+		offset = 0
 	}
 
-	// going negative when not at first byte
-	for i < 0 && position > 0 {
-		i += bitsPerByte
-
-		bs.OffsetPosition(-1)
+	if err := bs.seekBitOffset(offset); err != nil {
+		bs.err = &SeekError{Offset: offset, Whence: io.SeekStart, Err: err}
 	}
 
-	// going further than current byte
-	bs.OffsetPosition(i / bitsPerByte)
-
-	// ensure bit position within 0..7
-	bs.bitPosition = i % bitsPerByte
-
 	return bs
 }
 
@@ -250,11 +714,49 @@ func (bs *Reader) SetLittleEndian() *Reader {
 }
 
 // SetBigEndian makes the Reader read bits from the current byte from most-significant to least-significant.
+// This matches a Writer with the same bit order, see Writer.SetBigEndian.
 func (bs *Reader) SetBigEndian() *Reader {
 	bs.Options.endianness = BigEndian
 	return bs
 }
 
+// SetByteOrder sets the order of the bytes read by Bytes, when they are interpreted as an integer.
+// LittleEndian, the default, reads the least-significant byte first, and BigEndian the most-significant
+// byte first, so that r.Next(2).Bytes().AsUInt16() reads a big-endian 16-bit integer at any bit offset.
+// The bits of the Response stay in stream order, so AsBytes is unaffected.
+//
+// The byte order is independent of the bit order, which only arranges the bits within each byte,
+// see SetBigEndian. Fields which are most-significant bit first throughout, such as those of an
+// MPEG header, are instead read with SetBigEndian, Next(n).Bits() and Bits.AsUIntMSB.
+func (bs *Reader) SetByteOrder(order endianness) *Reader {
+	bs.Options.byteOrder = order
+	return bs
+}
+
+// SetSticky enables or disables sticky errors. Once a read of a sticky Reader fails, every later
+// read is a no-op, yielding zero bits and the same error. This allows a long sequence of fields
+// to be read with a single check of Err at the end, like a bufio.Scanner:
+//
+//	r := bitstream.ReaderFromBytes(data...).SetSticky(true)
+//	version, _ := r.Next(6).Bits().AsUInt()
+//	flags, _ := r.Next(10).Bits().AsUInt()
+//
+//	if err := r.Err(); err != nil {
+//		// handle it
+//	}
+//
+// A Marker also saves the error, so Reset can be used to recover from it.
+func (bs *Reader) SetSticky(sticky bool) *Reader {
+	bs.Options.sticky = sticky
+	return bs
+}
+
+// Err returns the first error encountered by a sticky Reader, as a *ReadError
+// that holds the bit offset of the failed read. It returns nil if there is no error.
+func (bs *Reader) Err() error {
+	return bs.stickyErr
+}
+
 // Next sets the integer count for the next "unit" of data read.
 // ex:
 //		instance.Next(2).Bytes().AsUInt64() will read 2 bytes and interpret as uint64
@@ -269,27 +771,86 @@ func (bs *Reader) Next(count int) *Reader {
 //
 // NOTE: The number is specified by by calling bitstream.Next
 //
+// If fewer bits could be read, the Response error is a *ShortReadError,
+// or a *ReadError if the Reader is sticky.
+//
 // example:
 //
 // val, err := bitstream.Next(2).Bytes()
 func (bs *Reader) Bits() Response {
 	bits, err := bs.readBits(bs.unitsToRead)
 
-	return Response{bits, err}
+	return Response{Bits: bits, Error: err}
 }
 
-// Bytes will read (bs.unitsToRead * 8) bits into a Response
+// Peek reads the next n bits into a Response, without moving the read position
+// or changing the number of bits read. Near the end of the stream, the Response
+// holds the bits that were available, and a *ShortReadError wrapping io.EOF.
+func (bs *Reader) Peek(n int) Response {
+	if bs.Options.sticky && bs.stickyErr != nil {
+		return Response{Bits: make(Bits, n), Error: bs.stickyErr}
+	}
+
+	if bs.err != nil {
+		return Response{Bits: make(Bits, n), Error: bs.err}
+	}
+
+	bits, _, err := bs.peekBits(n)
+
+	return Response{Bits: bits, Error: err}
+}
+
+// Bytes will read (bs.unitsToRead * 8) bits into a Response. Errors are as for Bits.
+// The Response interprets the bytes as an integer in the byte order of the Reader, see SetByteOrder.
 func (bs *Reader) Bytes() Response {
 	bits, err := bs.readBits(bs.unitsToRead * bitsPerByte)
 
-	return Response{bits, err}
+	return Response{Bits: bits, Error: err, byteOrder: bs.Options.byteOrder}
 }
 
-// Length returns the number of bytes
+// BitLength returns the number of bits which can be read from the start of the Reader,
+// which for a sub-reader is the length of its window.
+//
+// The length of a forward-only stream is unknown until its end has been read. Until
+// then, the error wraps ErrNotSeekable.
+func (bs *Reader) BitLength() (int64, error) {
+	if bs.stream == nil {
+		return 0, nil
+	}
+
+	if bs.limit >= 0 {
+		return bs.limit, nil
+	}
+
+	if bs.size < 0 {
+		if bs.seeker == nil {
+			return 0, fmt.Errorf("error getting Bitstream length: %w", ErrNotSeekable)
+		}
+
+		length, err := bs.seeker.Seek(0, io.SeekEnd)
+		if err != nil {
+			return 0, fmt.Errorf("error getting Bitstream length: %w", err)
+		}
+
+		bs.size = length
+	}
+
+	return bs.size * bitsPerByte, nil
+}
+
+// Length returns the number of bytes. For a sub-reader, this includes a final partial byte.
+//
+// The length of a forward-only stream is unknown until its end has been read. Until
+// then, Length returns -1; BitLength also yields the error.
 func (bs *Reader) Length() int {
-	current, _ := bs.stream.Seek(0, io.SeekCurrent)
-	length, _ := bs.stream.Seek(0, io.SeekEnd)
-	_, _ = bs.stream.Seek(current, io.SeekStart)
+	length, err := bs.BitLength()
+
+	switch {
+	case errors.Is(err, ErrNotSeekable):
+		return -1
+	case err != nil:
+		return 0
+	}
 
-	return int(length)
+	return int((length + bitsPerByte - 1) / bitsPerByte)
 }
@@ -1,10 +1,15 @@
 package bitstream
 
 import (
+	"bytes"
+	"compress/gzip"
 	"crypto/rand"
 	"errors"
+	"fmt"
 	"io"
 	"math/big"
+	"os"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -258,6 +263,136 @@ func TestBitStream_SetPosition(t *testing.T) {
 	}
 }
 
+func TestReader_SeekBit(t *testing.T) {
+	bs := ReaderFromBytes(0b_1000_0001, 0b_0100_0010, 0b_0010_0100)
+
+	tests := []struct {
+		name           string
+		offset         int64
+		whence         int
+		expectedOffset int64
+		expectedBit    bool
+		wantErr        bool
+	}{
+		{"from start", 7, io.SeekStart, 7, true, false},
+		{"from current, forward", 5, io.SeekCurrent, 13, false, false},
+		{"from current, backward", -13, io.SeekCurrent, 1, false, false},
+		{"from end", -3, io.SeekEnd, 21, true, false},
+		{"past the end", 30, io.SeekStart, 30, false, true},
+		{"before the start", -1, io.SeekStart, 31, false, true},
+		{"bad whence", 0, 3, 31, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			offset, err := bs.SeekBit(tt.offset, tt.whence)
+			if err == nil && offset != tt.expectedOffset {
+				t.Errorf("SeekBit() = %v, want %v", offset, tt.expectedOffset)
+			}
+
+			if bs.BitsRead() != 0 && err == nil {
+				t.Errorf("expected bits read to be reset by SeekBit(), got %v", bs.BitsRead())
+			}
+
+			bit, readErr := bs.Next(1).Bits().AsBool()
+
+			if (err != nil || readErr != nil) != tt.wantErr {
+				t.Errorf("SeekBit() error = %v, read error = %v, wantErr %v", err, readErr, tt.wantErr)
+			}
+
+			if bit != tt.expectedBit {
+				t.Errorf("expected bit at offset %v to be %v, got %v", tt.expectedOffset, tt.expectedBit, bit)
+			}
+
+			if got := bs.BitOffset(); got != tt.expectedOffset+1 && !tt.wantErr {
+				t.Errorf("BitOffset() = %v, want %v", got, tt.expectedOffset+1)
+			}
+
+			if got := int64(bs.Position()*bitsPerByte + bs.BitPosition()); got != bs.BitOffset() {
+				t.Errorf("BitOffset() = %v, but byte and bit positions give %v", bs.BitOffset(), got)
+			}
+		})
+	}
+}
+
+func TestReader_Peek(t *testing.T) {
+	// LSB first, the stream is 0110_0101 1111_0000
+	bs := ReaderFromBytes(0b_1010_0110, 0b_0000_1111)
+
+	tests := []struct {
+		name      string
+		offset    int64
+		toPeek    int
+		expected  uint
+		expectEOF bool
+	}{
+		{"at start", 0, 3, 0b110, false},
+		{"unaligned", 3, 7, 0b111_0100, false},
+		{"across bytes", 0, 9, 0b1_1010_0110, false},
+		{"up to the end", 11, 5, 0b0_0001, false},
+		{"past the end", 10, 8, 0b11, true},
+		{"at the end", 16, 1, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := bs.SeekBit(tt.offset, io.SeekStart); err != nil {
+				t.Fatal(err)
+			}
+
+			_ = bs.SetBitsRead(3)
+
+			peeked := bs.Peek(tt.toPeek)
+
+			if got, _ := peeked.AsUInt(); got != tt.expected {
+				t.Errorf("Peek() = %v, want %v", got, tt.expected)
+			}
+
+			if errors.Is(peeked.Error, io.EOF) != tt.expectEOF {
+				t.Errorf("Peek() error = %v, expect EOF %v", peeked.Error, tt.expectEOF)
+			}
+
+			if len(peeked.Bits) != tt.toPeek {
+				t.Errorf("expected %v bits, got %v", tt.toPeek, len(peeked.Bits))
+			}
+
+			assert.Equal(t, tt.offset, bs.BitOffset(), "Peek() moved the read position")
+			assert.Equal(t, 3, bs.BitsRead(), "Peek() changed the number of bits read")
+
+			read, err := bs.Next(tt.toPeek).Bits().AsUInt()
+			if (err != nil) != tt.expectEOF {
+				t.Errorf("unexpected read error %v", err)
+			}
+
+			assert.Equal(t, tt.expected, read, "reading yields different bits than peeking")
+		})
+	}
+}
+
+func TestReader_PeekForwardOnly(t *testing.T) {
+	data := make([]byte, readChunkSize+10)
+	data[readChunkSize+9] = 0xAB
+
+	bs := NewReader(forwardOnly{bytes.NewReader(data)})
+
+	_ = bs.Next(readChunkSize / 2).Bytes()
+
+	// peeking past the buffered chunk must keep the bytes at the read position
+	peeked, err := bs.Peek((readChunkSize/2 + 10) * bitsPerByte).AsBytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, byte(0xAB), peeked[len(peeked)-1], "unexpected last byte peeked")
+
+	read, err := bs.Next(readChunkSize/2 + 10).Bytes().AsBytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, peeked, read, "reading yields different bits than peeking")
+}
+
 func TestBitStream_ReadByte_AsByte(t *testing.T) {
 	bs := ReaderFromBytes(
 		128, 1, 15, 204,
@@ -294,6 +429,458 @@ func TestBitStream_ReadByte_AsByte(t *testing.T) {
 	}
 }
 
+func TestReader_StreamSource(t *testing.T) {
+	data := make([]byte, readChunkSize*3+5)
+
+	if _, err := rand.Read(data); err != nil {
+		t.Fatal(err)
+	}
+
+	fromBytes := ReaderFromBytes(data...)
+	fromStream := NewReader(bytes.NewReader(data))
+
+	assert.Equal(t, len(data), fromStream.Length(), "unexpected stream length")
+
+	jumped := false
+
+	for numBits := 1; ; numBits = numBits%64 + 1 {
+		expected, expectedErr := fromBytes.Next(numBits).Bits().AsUInt64()
+		got, err := fromStream.Next(numBits).Bits().AsUInt64()
+
+		if expected != got {
+			t.Fatalf("read %v bits at byte %v, expected %v but got %v", numBits, fromBytes.Position(), expected, got)
+		}
+
+		if (err == nil) != (expectedErr == nil) {
+			t.Fatalf("expected error %v, got %v", expectedErr, err)
+		}
+
+		if err != nil {
+			break
+		}
+
+		// jump backward across a chunk boundary, once
+		if !jumped && fromBytes.Position() > readChunkSize*2 {
+			jumped = true
+
+			fromBytes.OffsetBitPosition(-readChunkSize*bitsPerByte - 3)
+			fromStream.OffsetBitPosition(-readChunkSize*bitsPerByte - 3)
+		}
+	}
+
+	assert.Equal(t, fromBytes.BitsRead(), fromStream.BitsRead(), "unexpected number of bits read")
+}
+
+// forwardOnly hides the io.Seeker of the wrapped reader
+type forwardOnly struct {
+	io.Reader
+}
+
+func TestReader_ForwardOnly(t *testing.T) {
+	data := make([]byte, readChunkSize*2+17)
+
+	if _, err := rand.Read(data); err != nil {
+		t.Fatal(err)
+	}
+
+	var compressed bytes.Buffer
+
+	gz := gzip.NewWriter(&compressed)
+
+	if _, err := gz.Write(data); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	gzr, err := gzip.NewReader(&compressed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := ReaderFromBytes(data...)
+	bs := NewReader(gzr)
+
+	assert.Equal(t, -1, bs.Length(), "length of a forward-only stream should be unknown")
+
+	_, err = bs.BitLength()
+	assert.True(t, errors.Is(err, ErrNotSeekable), "expected ErrNotSeekable from BitLength, got %v", err)
+
+	_, err = bs.Copy().Next(1).Bits().AsUInt()
+	assert.True(t, errors.Is(err, ErrNotSeekable), "expected ErrNotSeekable from a copy, got %v", err)
+
+	for numBits := 1; ; numBits = numBits%64 + 1 {
+		want, wantErr := expected.Next(numBits).Bits().AsUInt64()
+		got, err := bs.Next(numBits).Bits().AsUInt64()
+
+		if want != got || (err == nil) != (wantErr == nil) {
+			t.Fatalf("read %v bits at byte %v, expected (%v, %v) but got (%v, %v)",
+				numBits, expected.Position(), want, wantErr, got, err)
+		}
+
+		if err != nil {
+			break
+		}
+
+		if numBits == 32 {
+			position, bitPosition := bs.Position(), bs.BitPosition()
+
+			_, err = bs.OffsetBitPosition(-1).Next(1).Bits().AsUInt()
+			assert.True(t, errors.Is(err, ErrNotSeekable), "expected ErrNotSeekable moving backward, got %v", err)
+			assert.Equal(t, position, bs.Position(), "position changed after failing to move backward")
+			assert.Equal(t, bitPosition, bs.BitPosition(), "bit position changed after failing to move backward")
+
+			// skipping forward discards the bytes in between
+			expected.OffsetPosition(readChunkSize / 3)
+			bs.OffsetPosition(readChunkSize / 3)
+		}
+	}
+
+	assert.Equal(t, len(data), bs.Length(), "length should be known after reading to the end")
+
+	length, err := bs.BitLength()
+	assert.NoError(t, err, "BitLength should not fail after reading to the end")
+	assert.Equal(t, int64(len(data)*bitsPerByte), length, "unexpected bit length")
+}
+
+func TestReader_LengthForwardOnly(t *testing.T) {
+	bs := NewReader(forwardOnly{bytes.NewReader([]byte{0x12, 0x34})})
+
+	// asking for the length does not affect the next read
+	assert.Equal(t, -1, bs.Length(), "length of a forward-only stream should be unknown")
+
+	got, err := bs.Next(8).Bits().AsUInt()
+	assert.NoError(t, err, "unexpected error after Length")
+	assert.Equal(t, uint(0x12), got, "unexpected value returned")
+	assert.Equal(t, 8, bs.BitsRead(), "unexpected number of bits read")
+}
+
+func TestReader_Pipe(t *testing.T) {
+	data := []byte{0x12, 0x34, 0x56, 0x78}
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer r.Close()
+
+	go func() {
+		_, _ = w.Write(data)
+		_ = w.Close()
+	}()
+
+	// *os.File is an io.ReadSeeker, but a pipe can not seek
+	bs := NewReader(r)
+
+	_, err = bs.Seek(0, io.SeekEnd)
+	assert.True(t, errors.Is(err, ErrNotSeekable), "expected ErrNotSeekable, got %v", err)
+
+	got, err := bs.Next(4).Bytes().AsUInt32()
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.Equal(t, uint32(0x78563412), got, "unexpected value returned")
+
+	_, err = bs.Next(1).Bits().AsUInt()
+	assert.True(t, errors.Is(err, io.EOF), "expected EOF, got %v", err)
+}
+
+func TestReader_StreamPastEnd(t *testing.T) {
+	bs := NewReader(bytes.NewReader([]byte{1, 2, 3}))
+
+	// reading past the end of the stream does not change its length
+	_, err := bs.SetPosition(100).Next(1).Bits().AsUInt()
+	assert.True(t, errors.Is(err, io.EOF), "expected EOF past the end, got %v", err)
+	assert.Equal(t, 3, bs.Length(), "unexpected stream length")
+
+	offset, err := bs.SeekBit(0, io.SeekEnd)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(24), offset, "unexpected offset of the end")
+
+	got := bs.SetPosition(1).Copy().Next(40).Bits()
+	assert.True(t, errors.Is(got.Error, io.EOF), "expected EOF from a copy, got %v", got.Error)
+	assert.Equal(t, uint(0x0302), got.Bits.AsUInt(), "unexpected value returned")
+}
+
+func TestNewReader_Sources(t *testing.T) {
+	stream := bytes.NewReader([]byte{0, 1, 2, 3})
+
+	if _, err := stream.Seek(2, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+
+	// reading starts at the current offset of the stream
+	bs := NewReader(stream)
+	assert.Equal(t, 2, bs.Position(), "unexpected starting position")
+
+	got, err := bs.Next(1).Bytes().AsByte()
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.Equal(t, byte(2), got, "unexpected value returned")
+
+	got, err = NewReader(forwardOnly{bytes.NewReader([]byte{7})}).Next(1).Bytes().AsByte()
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.Equal(t, byte(7), got, "unexpected value returned")
+}
+
+func TestReader_Sub(t *testing.T) {
+	data := []byte{0b_1010_0110, 0b_0000_1111, 0b_1100_0011, 0b_0101_0101}
+	parent := ReaderFromBytes(data...)
+
+	_ = parent.Next(3).Bits()
+
+	sub := parent.Sub(5, 14)
+
+	assert.Equal(t, 0, sub.Position(), "sub-reader should start at position 0")
+	assert.Equal(t, 0, sub.BitPosition(), "sub-reader should start at bit position 0")
+	assert.Equal(t, 2, sub.Length(), "unexpected sub-reader length")
+
+	got, err := sub.Next(14).Bits().AsUInt()
+	if err != nil {
+		t.Error(err)
+	}
+
+	expected, _ := ReaderFromBytes(data...).SetBitPosition(5).Next(14).Bits().AsUInt()
+	assert.Equal(t, expected, got, "unexpected value returned")
+	assert.Equal(t, int64(3), parent.BitOffset(), "reading a sub-reader moved the parent")
+
+	_, err = sub.Next(1).Bits().AsUInt()
+	assert.True(t, errors.Is(err, io.EOF), "expected EOF at the end of the window, got %v", err)
+
+	// the window is relative to the sub-reader, and truncated at its end
+	nested := sub.Sub(10, 100)
+
+	offset, err := nested.SeekBit(0, io.SeekEnd)
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.Equal(t, int64(4), offset, "unexpected length of a truncated window")
+
+	if _, err = nested.SeekBit(0, io.SeekStart); err != nil {
+		t.Error(err)
+	}
+
+	got, err = nested.Next(4).Bits().AsUInt()
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.Equal(t, expected>>10, got, "unexpected value returned")
+
+	// the data is shared, not copied
+	data[1] = 0xFF
+
+	got, err = sub.SetPosition(0).SetBitPosition(3).Next(8).Bits().AsUInt()
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.Equal(t, uint(0xFF), got, "sub-reader does not share data with the parent")
+
+	_, err = parent.Sub(-1, 3).Next(1).Bits().AsUInt()
+	assert.Error(t, err, "expected an error for a negative offset")
+
+	_, err = NewReader(forwardOnly{bytes.NewReader(data)}).Sub(0, 8).Next(1).Bits().AsUInt()
+	assert.True(t, errors.Is(err, ErrNotSeekable), "expected ErrNotSeekable, got %v", err)
+}
+
+func TestReader_SubOversized(t *testing.T) {
+	for name, parent := range map[string]*Reader{
+		"bytes":  ReaderFromBytes(1, 2, 3),
+		"stream": NewReader(bytes.NewReader([]byte{1, 2, 3})),
+	} {
+		t.Run(name, func(t *testing.T) {
+			// the window is truncated at the end of the stream
+			sub := parent.Sub(8, 1000)
+			assert.Equal(t, 2, sub.Length(), "unexpected sub-reader length")
+
+			for _, r := range []*Reader{sub, sub.Copy()} {
+				got := r.Next(40).Bits()
+
+				assert.True(t, errors.Is(got.Error, io.EOF), "expected EOF at the end of the stream, got %v", got.Error)
+				assert.Equal(t, uint(0x0302), got.Bits.AsUInt(), "unexpected value returned")
+			}
+
+			offset, err := sub.SeekBit(0, io.SeekEnd)
+			assert.NoError(t, err)
+			assert.Equal(t, int64(16), offset, "unexpected length of a truncated window")
+		})
+	}
+}
+
+func TestReader_SubStream(t *testing.T) {
+	data := make([]byte, readChunkSize*2)
+
+	if _, err := rand.Read(data); err != nil {
+		t.Fatal(err)
+	}
+
+	parent := NewReader(bytes.NewReader(data))
+	sub := parent.Sub(readChunkSize*bitsPerByte-4, 72)
+
+	// interleave reads, which refill the buffers of both readers from the shared stream
+	for idx := 0; idx < 9; idx++ {
+		_ = parent.Next(1).Bytes()
+
+		got, err := sub.Next(8).Bits().AsByte()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expected, _ := ReaderFromBytes(data...).SetPosition(readChunkSize - 1).SetBitPosition(4 + idx*8).Next(8).Bits().AsByte()
+		assert.Equal(t, expected, got, "unexpected byte %v in the window", idx)
+	}
+
+	copied := sub.Copy().SetPosition(8)
+
+	got, err := copied.Next(8).Bits().AsByte()
+	if err != nil {
+		t.Error(err)
+	}
+
+	expected, _ := sub.SetPosition(8).Next(8).Bits().AsByte()
+	assert.Equal(t, expected, got, "copy of a sub-reader differs")
+
+	_, err = copied.Next(1).Bits().AsByte()
+	assert.True(t, errors.Is(err, io.EOF), "expected EOF at the end of the copied window, got %v", err)
+}
+
+func TestReader_Sticky(t *testing.T) {
+	bs := ReaderFromBytes(0b_1010_0110, 0b_0000_1111).SetSticky(true)
+
+	a, _ := bs.Next(4).Bits().AsUInt()
+	b, _ := bs.Next(7).Bits().AsUInt()
+	c, errC := bs.Next(8).Bits().AsUInt() // only 5 bits remain
+	d, errD := bs.Next(1).Bits().AsUInt()
+	e, errE := bs.Peek(1).AsUInt()
+
+	assert.Equal(t, uint(0b0110), a, "unexpected value returned")
+	assert.Equal(t, uint(0b111_1010), b, "unexpected value returned")
+	assert.Equal(t, uint(0b0_0001), c, "the failed read should yield the bits that were available")
+	assert.Equal(t, uint(0), d, "reads after the first error should yield zero")
+	assert.Equal(t, uint(0), e, "peeks after the first error should yield zero")
+
+	err := bs.Err()
+
+	var readErr *ReadError
+	if !errors.As(err, &readErr) {
+		t.Fatalf("expected a *ReadError, got %v", err)
+	}
+
+	assert.Equal(t, int64(11), readErr.BitOffset, "unexpected bit offset of the error")
+	assert.True(t, errors.Is(err, io.EOF), "expected EOF, got %v", err)
+	assert.Equal(t, err, errC, "the failed read should yield the sticky error")
+	assert.Equal(t, err, errD, "later reads should yield the sticky error")
+	assert.Equal(t, err, errE, "later peeks should yield the sticky error")
+	assert.Equal(t, int64(16), bs.BitOffset(), "reads after the first error should not move the read position")
+}
+
+func TestReader_StickyReset(t *testing.T) {
+	bs := ReaderFromBytes(0b_1010_0110).SetSticky(true)
+
+	m := bs.Mark()
+
+	_ = bs.Next(9).Bits()
+	assert.Error(t, bs.Err(), "expected an error reading past the end")
+
+	if err := bs.Reset(m); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NoError(t, bs.Err(), "reset should restore the error")
+
+	got, err := bs.Next(8).Bits().AsUInt()
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.Equal(t, uint(0b_1010_0110), got, "unexpected value returned")
+
+	// an error from a method which can not return one becomes sticky when a read yields it
+	forward := NewReader(forwardOnly{bytes.NewReader([]byte{1, 2})}).SetSticky(true)
+
+	_ = forward.Next(8).Bits()
+	_ = forward.OffsetBitPosition(-1).Next(1).Bits()
+	assert.True(t, errors.Is(forward.Err(), ErrNotSeekable), "expected ErrNotSeekable, got %v", forward.Err())
+
+	plain := ReaderFromBytes(1)
+
+	_ = plain.Next(9).Bits()
+	assert.NoError(t, plain.Err(), "a Reader that is not sticky has no sticky error")
+
+	if _, err = plain.SeekBit(0, io.SeekStart); err != nil {
+		t.Error(err)
+	}
+
+	_, err = plain.Next(1).Bits().AsUInt()
+	assert.NoError(t, err, "a Reader that is not sticky should read after an error")
+}
+
+func TestReader_Concurrent(t *testing.T) {
+	const (
+		numStreams = 32
+		numBits    = 13
+	)
+
+	data := make([]byte, readChunkSize+123)
+
+	if _, err := rand.Read(data); err != nil {
+		t.Fatal(err)
+	}
+
+	decode := func(bs *Reader) []uint64 {
+		values := make([]uint64, 0, len(data)*bitsPerByte/numBits)
+
+		for {
+			v, err := bs.Next(numBits).Bits().AsUInt64()
+			if err != nil {
+				return values
+			}
+
+			values = append(values, v)
+		}
+	}
+
+	expected := decode(ReaderFromBytes(data...))
+
+	var wg sync.WaitGroup
+
+	results := make([][]uint64, numStreams)
+
+	for idx := range results {
+		wg.Add(1)
+
+		go func(idx int) {
+			defer wg.Done()
+
+			// alternate between readers sharing the byte slice and readers with their own stream
+			if idx%2 == 0 {
+				results[idx] = decode(ReaderFromBytes(data...))
+			} else {
+				results[idx] = decode(NewReader(bytes.NewReader(data)))
+			}
+
+			_ = BitsFromByte(data[idx])
+		}(idx)
+	}
+
+	wg.Wait()
+
+	for idx := range results {
+		assert.Equal(t, expected, results[idx], "stream %v decoded differently", idx)
+	}
+}
+
 func BenchmarkBitStream_ReadBits(b *testing.B) {
 	bytes := make([]byte, 1024)
 
@@ -382,7 +969,7 @@ func TestBitstream_Copy(t *testing.T) {
 		0b_0000_0010,
 		0b_1111_0011,
 		0b_0000_0100,
-		)
+	)
 
 	_ = original.Next(8).Bits() // skip
 
@@ -528,3 +1115,132 @@ func TestBitstream_Copy(t *testing.T) {
 //		numBits--
 //	}
 // }
+
+func BenchmarkReader_Sequential(b *testing.B) {
+	const numBytes = 64 * 1024
+
+	data := make([]byte, numBytes)
+
+	if _, err := rand.Read(data); err != nil {
+		b.Error(err)
+	}
+
+	for _, numBits := range []int{1, 7, 8, 13, 32, 64} {
+		numBits := numBits
+
+		b.Run(fmt.Sprintf("%dbit reads", numBits), func(b *testing.B) {
+			b.SetBytes(numBytes)
+
+			for i := 0; i < b.N; i++ {
+				bs := ReaderFromBytes(data...)
+
+				for n := 0; n < numBytes*bitsPerByte/numBits; n++ {
+					_, _ = bs.Next(numBits).Bits().AsUInt64()
+				}
+			}
+		})
+
+		b.Run(fmt.Sprintf("%dbit reads (from io.ReadSeeker)", numBits), func(b *testing.B) {
+			b.SetBytes(numBytes)
+
+			for i := 0; i < b.N; i++ {
+				bs := NewReader(bytes.NewReader(data))
+
+				for n := 0; n < numBytes*bitsPerByte/numBits; n++ {
+					_, _ = bs.Next(numBits).Bits().AsUInt64()
+				}
+			}
+		})
+	}
+}
+
+func TestReader_ByteOrder(t *testing.T) {
+	// LSB first, the stream is 1000_0100 1100_0010 1010_0000
+	data := []byte{0x21, 0x43, 0x05}
+
+	tests := []struct {
+		name      string
+		bitOrder  endianness
+		byteOrder endianness
+		offset    int64
+		expected  uint16
+	}{
+		{"little endian bits and bytes", LittleEndian, LittleEndian, 0, 0x4321},
+		{"little endian bits and bytes, unaligned", LittleEndian, LittleEndian, 4, 0x5432},
+		{"little endian bits, big endian bytes", LittleEndian, BigEndian, 0, 0x2143},
+		{"little endian bits, big endian bytes, unaligned", LittleEndian, BigEndian, 4, 0x3254},
+		// MSB first, the stream is 0010_0001 0100_0011 0000_0101, each byte read back to front
+		{"big endian bits, little endian bytes", BigEndian, LittleEndian, 0, 0xC284},
+		{"big endian bits, little endian bytes, unaligned", BigEndian, LittleEndian, 4, 0x0C28},
+		{"big endian bits and bytes", BigEndian, BigEndian, 0, 0x84C2},
+		{"big endian bits and bytes, unaligned", BigEndian, BigEndian, 4, 0x280C},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bs := ReaderFromBytes(data...).SetByteOrder(tt.byteOrder)
+			if tt.bitOrder == BigEndian {
+				bs.SetBigEndian()
+			}
+
+			if _, err := bs.SeekBit(tt.offset, io.SeekStart); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := bs.Next(2).Bytes().AsUInt16()
+			if err != nil {
+				t.Fatalf("Bytes() error = %v", err)
+			}
+
+			if got != tt.expected {
+				t.Errorf("AsUInt16() = %#04x, want %#04x", got, tt.expected)
+			}
+
+			assert.Equal(t, tt.offset+16, bs.BitOffset(), "unexpected offset after reading")
+		})
+	}
+}
+
+func TestReader_ByteOrderBits(t *testing.T) {
+	// the byte order only applies to integers read by Bytes, not to Bits or Peek
+	bs := ReaderFromBytes(0x12, 0x34).SetByteOrder(BigEndian)
+
+	peeked, _ := bs.Peek(16).AsUInt16()
+	assert.Equal(t, uint16(0x3412), peeked, "unexpected Peek")
+
+	read, _ := bs.Next(16).Bits().AsUInt16()
+	assert.Equal(t, uint16(0x3412), read, "unexpected Bits")
+
+	_, _ = bs.SeekBit(0, io.SeekStart)
+
+	read, _ = bs.Next(2).Bytes().AsUInt16()
+	assert.Equal(t, uint16(0x1234), read, "unexpected Bytes")
+
+	// the 8-bit methods yield the least-significant byte, as for a little-endian Reader
+	for order, want := range map[endianness]uint8{LittleEndian: 0x12, BigEndian: 0x34} {
+		res := ReaderFromBytes(0x12, 0x34).SetByteOrder(order).Next(2).Bytes()
+		wide, _ := res.AsUInt16()
+
+		low, _ := res.AsUInt8()
+		assert.Equal(t, want, low, "byte order %v: unexpected AsUInt8", order)
+		assert.Equal(t, uint8(wide), low, "byte order %v: AsUInt8 is not the low byte of AsUInt16", order)
+
+		lowByte, _ := res.AsByte()
+		assert.Equal(t, uint8(wide), lowByte, "byte order %v: unexpected AsByte", order)
+
+		lowSigned, _ := res.AsInt8()
+		assert.Equal(t, int8(wide), lowSigned, "byte order %v: unexpected AsInt8", order)
+
+		nonZero, _ := res.AsBool()
+		assert.True(t, nonZero, "byte order %v: unexpected AsBool", order)
+	}
+
+	_, _ = bs.SeekBit(0, io.SeekStart)
+
+	// the bits of the Response stay in stream order
+	got := bs.Next(2).Bytes()
+	assert.Equal(t, Bits{F, T, F, F, T, F, F, F, F, F, T, F, T, T, F, F}, got.Bits, "unexpected Bits of Bytes")
+
+	bytes, _ := got.AsBytes()
+	assert.Equal(t, []byte{0x12, 0x34}, bytes, "unexpected AsBytes")
+}
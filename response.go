@@ -1,19 +1,26 @@
 package bitstream
 
-// Response represents a response of Reader
+// Response represents a response of Reader. Error is nil when every requested bit
+// was read; otherwise it is usually a *ShortReadError, see Reader.Bits.
+//
+// For a Response from Reader.Bytes, every method but AsBytes interprets the bytes as an
+// integer in the byte order of the Reader, see Reader.SetByteOrder, so that AsUInt8 yields
+// the least-significant byte of AsUInt16 either way. The Bits themselves, and so AsBytes,
+// are always in stream order.
 type Response struct {
 	Bits
-	Error error
+	Error     error
+	byteOrder endianness // the byte order of a Response from Reader.Bytes
 }
 
 // AsBool interprets the bits as a bool
 func (res Response) AsBool() (bool, error) {
-	return res.Bits.AsBool(), res.Error
+	return res.ordered().AsBool(), res.Error
 }
 
 // AsByte interprets the bits as a byte
 func (res Response) AsByte() (byte, error) {
-	return res.Bits.AsByte(), res.Error
+	return res.ordered().AsByte(), res.Error
 }
 
 // AsBytes interprets the bits as a slice of bytes
@@ -23,50 +30,123 @@ func (res Response) AsBytes() ([]byte, error) {
 
 // AsInt8 interprets the bits as a signed 8-bit integer
 func (res Response) AsInt8() (int8, error) {
-	return res.Bits.AsInt8(), res.Error
+	return res.ordered().AsInt8(), res.Error
 }
 
 // AsUInt8 interprets the bits as a usnigned 8-bit integer
 func (res Response) AsUInt8() (uint8, error) {
-	return res.Bits.AsUInt8(), res.Error
+	return res.ordered().AsUInt8(), res.Error
 }
 
 // AsInt16 interprets the bits as a signed 16-bit integer
 func (res Response) AsInt16() (int16, error) {
-	return res.Bits.AsInt16(), res.Error
+	return res.ordered().AsInt16(), res.Error
 }
 
 // AsUInt16 interprets the bits as an unsugned 16-bit integer
 func (res Response) AsUInt16() (uint16, error) {
-	return res.Bits.AsUInt16(), res.Error
+	return res.ordered().AsUInt16(), res.Error
 }
 
 // AsInt32 interprets the bits as a signed 32-bit integer
 func (res Response) AsInt32() (int32, error) {
-	return res.Bits.AsInt32(), res.Error
+	return res.ordered().AsInt32(), res.Error
 }
 
 // AsUInt32 interprets the bits as an unsigned 32-bit integer
 func (res Response) AsUInt32() (uint32, error) {
-	return res.Bits.AsUInt32(), res.Error
+	return res.ordered().AsUInt32(), res.Error
 }
 
 // AsInt64 interprets the bits as a signed 64-bit integer
 func (res Response) AsInt64() (int64, error) {
-	return res.Bits.AsInt64(), res.Error
+	return res.ordered().AsInt64(), res.Error
 }
 
 // AsUInt64 interprets the bits as an unsigned 64-bit integer
 func (res Response) AsUInt64() (uint64, error) {
-	return res.Bits.AsUInt64(), res.Error
+	return res.ordered().AsUInt64(), res.Error
 }
 
 // AsInt interprets the bits as a signed integer
 func (res Response) AsInt() (int, error) {
-	return res.Bits.AsInt(), res.Error
+	return res.ordered().AsInt(), res.Error
 }
 
 // AsUInt interprets the bits as an unsigned integer
 func (res Response) AsUInt() (uint, error) {
-	return res.Bits.AsUInt(), res.Error
+	return res.ordered().AsUInt(), res.Error
+}
+
+// AsInt8MSB interprets the bits as a signed 8-bit integer, most-significant bit first
+func (res Response) AsInt8MSB() (int8, error) {
+	return res.Bits.AsInt8MSB(), res.Error
+}
+
+// AsUInt8MSB interprets the bits as an unsigned 8-bit integer, most-significant bit first
+func (res Response) AsUInt8MSB() (uint8, error) {
+	return res.Bits.AsUInt8MSB(), res.Error
+}
+
+// AsInt16MSB interprets the bits as a signed 16-bit integer, most-significant bit first
+func (res Response) AsInt16MSB() (int16, error) {
+	return res.Bits.AsInt16MSB(), res.Error
+}
+
+// AsUInt16MSB interprets the bits as an unsigned 16-bit integer, most-significant bit first
+func (res Response) AsUInt16MSB() (uint16, error) {
+	return res.Bits.AsUInt16MSB(), res.Error
+}
+
+// AsInt32MSB interprets the bits as a signed 32-bit integer, most-significant bit first
+func (res Response) AsInt32MSB() (int32, error) {
+	return res.Bits.AsInt32MSB(), res.Error
+}
+
+// AsUInt32MSB interprets the bits as an unsigned 32-bit integer, most-significant bit first
+func (res Response) AsUInt32MSB() (uint32, error) {
+	return res.Bits.AsUInt32MSB(), res.Error
+}
+
+// AsInt64MSB interprets the bits as a signed 64-bit integer, most-significant bit first
+func (res Response) AsInt64MSB() (int64, error) {
+	return res.Bits.AsInt64MSB(), res.Error
+}
+
+// AsUInt64MSB interprets the bits as an unsigned 64-bit integer, most-significant bit first
+func (res Response) AsUInt64MSB() (uint64, error) {
+	return res.Bits.AsUInt64MSB(), res.Error
+}
+
+// AsIntMSB interprets the bits as a signed integer, most-significant bit first
+func (res Response) AsIntMSB() (int, error) {
+	return res.Bits.AsIntMSB(), res.Error
+}
+
+// AsUIntMSB interprets the bits as an unsigned integer, most-significant bit first
+func (res Response) AsUIntMSB() (uint, error) {
+	return res.Bits.AsUIntMSB(), res.Error
+}
+
+// ordered returns the bits with their bytes arranged least-significant first, as the
+// integer methods of Bits expect, copying them if the byte order is BigEndian
+func (res Response) ordered() Bits {
+	if res.byteOrder != BigEndian {
+		return res.Bits
+	}
+
+	b := make(Bits, len(res.Bits))
+	copy(b, res.Bits)
+	reverseBytes(b)
+
+	return b
+}
+
+// reverseBytes reverses the order of the bytes of b in place, keeping the order of the bits within each byte
+func reverseBytes(b Bits) {
+	for lo, hi := 0, len(b)-bitsPerByte; lo < hi; lo, hi = lo+bitsPerByte, hi-bitsPerByte {
+		for idx := 0; idx < bitsPerByte; idx++ {
+			b[lo+idx], b[hi+idx] = b[hi+idx], b[lo+idx]
+		}
+	}
 }
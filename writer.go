@@ -1,87 +1,489 @@
 package bitstream
 
-import "fmt"
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math/bits"
+)
+
+// writeChunkSize is the number of whole bytes a streaming Writer buffers before writing them out
+const writeChunkSize = 4096
 
 // Writer is a stream writer, capable of writing data which is not byte-aligned.
 // CAVEAT: the resulting byte buffer WILL be byte-aligned, as the underlying representation
 // of the bits is in a byte slice.
+//
+// A Writer is not safe for concurrent use, but separate Writers can be used concurrently.
+//
+// Writing bits and bytes to the byte buffer can not fail, but the methods which take a width,
+// a value or a position return an error for one which is not valid:
+//   - WriteUint and WriteInt, an error wrapping ErrInvalidWidth or ErrOverflow
+//   - Reserve and Truncate, an error wrapping ErrInvalidWidth, or ErrNotSeekable for Truncate
+//   - PadTo, an error wrapping ErrOverflow
+//   - SeekBit, Seek, WriteBitsAt and WriteAt, a *SeekError
+//   - Write, an *ArgumentError
+//
+// A Writer created by NewWriterTo also returns the errors of its io.Writer, or ErrClosed after
+// Close, from every write method.
 type Writer struct {
-	// all of the bytes written by this Writer
+	// all of the bytes written by this Writer, or for a streaming Writer, those not yet flushed
 	bytes     []byte
 
-	// bitBuffer is a single byte used for storing individual bits that are written.
-	// When a byte-boundary is passed, this byte is appended to the bytes slice
+	// bitBuffer is a 64-bit accumulator for the bits that are written, arranged as the little-endian
+	// bytes they will become. When it is full, all 8 bytes are appended to the bytes slice at once.
 	//
-	// NOTE: calling Bytes() while bitOffset != 0 will append this bitBuffer as a final byte.
-	bitBuffer byte
+	// NOTE: calling Bytes() while bitOffset != 0 will append the used bytes of this bitBuffer.
+	bitBuffer uint64
 
-	// bitOffset is incremented every time a bit is written. It wraps modulo 8 (bits per byte).
+	// bitOffset is the number of bits in the bitBuffer. It wraps modulo 64 (bits per word).
 	bitOffset int
 
-	// endianness determines the order in which bits are written into the bitBuffer
+	// endianness determines the order in which bits are written into each byte of the bitBuffer
 	endianness
+
+	// byteOrder determines the order of the bytes of a whole-byte integer, see SetByteOrder
+	byteOrder endianness
+
+	// sink receives the whole bytes of a streaming Writer, see NewWriterTo
+	sink io.Writer
+
+	// err is the first error from the sink, or ErrClosed after Close. Every later write yields it.
+	err error
+
+	// flushed is the number of bytes written to the sink
+	flushed int64
+
+	// reserved holds the placeholders which are not filled yet. Bytes from the first of them onward are not flushed.
+	reserved []*Placeholder
+
+	// padding is the repeating pattern of padding bits, zeros if empty, see SetPadding
+	padding Bits
+
+	// back is the number of bits from the write position to the end of the written bits, see SeekBit.
+	// It is negative after seeking past the end, and the gap is filled with zeros by the next write.
+	back int64
+}
+
+// NewWriter creates a Writer with room for nBits bits, so that writing them does not reallocate.
+// Together with Reset, it suits a sync.Pool:
+//
+//	pool := sync.Pool{New: func() interface{} { return bitstream.NewWriter(1500 * 8) }}
+//
+//	w := pool.Get().(*bitstream.Writer)
+//	// ... write and use w.Bytes()
+//	w.Reset()
+//	pool.Put(w)
+func NewWriter(nBits int) *Writer {
+	w := &Writer{}
+	w.Grow(nBits)
+
+	return w
+}
+
+// NewWriterTo creates a Writer which streams to the given io.Writer. Whole bytes are
+// buffered and written out as they fill, so only a small chunk is ever held in memory;
+// Flush writes out whatever is buffered, and Close flushes the final partial byte.
+func NewWriterTo(sink io.Writer) *Writer {
+	return &Writer{
+		sink:  sink,
+		bytes: make([]byte, 0, writeChunkSize+bytesPerWord),
+	}
+}
+
+// Flush writes the buffered whole bytes to the io.Writer given to NewWriterTo,
+// stopping at the first placeholder from Reserve which is not filled yet.
+// If pad is true and a byte is partially written, it is padded with zero bits and
+// written too, so that the next bit starts a new byte; otherwise it stays buffered.
+// For a Writer without an io.Writer, only the padding has any effect.
+func (w *Writer) Flush(pad bool) error {
+	if w.err != nil {
+		return w.err
+	}
+
+	if pad && w.bitOffset%bitsPerByte != 0 {
+		padBits := bitsPerByte - w.bitOffset%bitsPerByte
+
+		// away from the end, the write position stays where it is
+		if w.back != 0 {
+			w.back += int64(padBits)
+		}
+
+		w.bitOffset += padBits
+	}
+
+	w.spill()
+
+	if w.sink == nil {
+		return nil
+	}
+
+	return w.flushBytes()
+}
+
+// BitOffset returns the write position, as a bit offset from the start of the stream
+func (w *Writer) BitOffset() int64 {
+	return w.BitLen() - w.back
+}
+
+// BitLen returns the exact number of bits written, including any which were flushed.
+// The length in bytes of the output is this, divided by 8 and rounded up.
+func (w *Writer) BitLen() int64 {
+	return (w.flushed+int64(len(w.bytes)))*bitsPerByte + int64(w.bitOffset)
+}
+
+// Truncate discards all but the first nBits bits written, including those of a partially written
+// byte, and moves the write position to the new end. The bits of a partial byte are kept in
+// the current bit order. A streaming Writer can not truncate bytes which were already flushed.
+//
+// Placeholders in the discarded bits can no longer be filled, and report ErrPlaceholderFilled.
+func (w *Writer) Truncate(nBits int64) error {
+	if nBits < 0 || nBits > w.BitLen() {
+		return fmt.Errorf("can not truncate %v bits to %v bits: %w", w.BitLen(), nBits, ErrInvalidWidth)
+	}
+
+	if nBits < w.flushed*bitsPerByte {
+		return fmt.Errorf("can not truncate to %v bits, %v bytes were flushed: %w", nBits, w.flushed, ErrNotSeekable)
+	}
+
+	// with every bit in the byte slice, there is a single place to cut
+	w.spill()
+
+	if w.bitOffset != 0 {
+		w.bytes = append(w.bytes, byte(w.bitBuffer))
+	}
+
+	numBytes := nBits/bitsPerByte - w.flushed
+	numBits := int(nBits % bitsPerByte)
+
+	w.bitBuffer = 0
+	w.bitOffset = numBits
+
+	if numBits != 0 {
+		w.bitBuffer = uint64(w.bytes[numBytes] & byte(w.arrange((1<<uint(numBits))-1)))
+	}
+
+	w.bytes = w.bytes[:numBytes]
+	w.back = 0
+
+	w.retire(func(p *Placeholder) bool {
+		return p.offset+int64(p.nBits) > nBits
+	})
+
+	return nil
+}
+
+// Grow makes room for another nBits bits, so that writing them does not reallocate the
+// byte buffer. It panics if nBits is negative, like bytes.Buffer.Grow.
+func (w *Writer) Grow(nBits int) {
+	if nBits < 0 {
+		panic("bitstream.Writer.Grow: negative count")
+	}
+
+	// the bitBuffer is appended a whole word at a time
+	numWords := (w.bitOffset + nBits + bitsPerWord - 1) / bitsPerWord
+
+	if need := numWords * bytesPerWord; cap(w.bytes)-len(w.bytes) < need {
+		bytes := make([]byte, len(w.bytes), 2*cap(w.bytes)+need)
+		copy(bytes, w.bytes)
+		w.bytes = bytes
+	}
+}
+
+// Reset discards everything written, but keeps the capacity of the byte buffer, the bit and byte order,
+// and the io.Writer of a streaming Writer, which is not flushed. The Writer can then be reused,
+// even after Close. Placeholders which were not filled can no longer be filled.
+func (w *Writer) Reset() {
+	w.retire(func(*Placeholder) bool { return true })
+
+	w.bytes = w.bytes[:0]
+	w.bitBuffer = 0
+	w.bitOffset = 0
+	w.err = nil
+	w.flushed = 0
+	w.back = 0
+}
+
+// SeekBit sets the write position to the given bit offset, interpreting whence as io.Seeker
+// does, and returns the new offset. Writing at a position before the end overwrites the bits
+// there, and extends the stream past the end. Seeking past the end is allowed; the gap is
+// filled with zero bits by the next write.
+//
+// A Writer created by NewWriterTo can not seek to bytes which were already flushed, and
+// does not flush bytes from the write position onward. The error is a *SeekError,
+// wrapping ErrNegativeOffset, ErrInvalidWhence or ErrNotSeekable.
+func (w *Writer) SeekBit(offset int64, whence int) (int64, error) {
+	target, err := w.seekTarget(offset, whence, w.BitOffset(), 1)
+	if err != nil {
+		return 0, &SeekError{Offset: offset, Whence: whence, Err: err}
+	}
+
+	if err := w.seekBitOffset(target); err != nil {
+		return 0, &SeekError{Offset: offset, Whence: whence, Err: err}
+	}
+
+	return target, nil
+}
+
+// Seek sets the write position to the given byte offset, interpreting whence as io.Seeker does,
+// and returns the new byte offset. The bit position within the byte is unchanged. See SeekBit.
+func (w *Writer) Seek(offset int64, whence int) (int64, error) {
+	current := w.BitOffset()
+
+	position, err := w.seekTarget(offset, whence, current/bitsPerByte, bitsPerByte)
+	if err != nil {
+		return 0, &SeekError{Offset: offset, Whence: whence, Err: err}
+	}
+
+	if err := w.seekBitOffset(position*bitsPerByte + current%bitsPerByte); err != nil {
+		return 0, &SeekError{Offset: offset, Whence: whence, Err: err}
+	}
+
+	return position, nil
+}
+
+// seekTarget resolves an offset in units of bitsPerUnit bits, interpreting whence as io.Seeker does
+func (w *Writer) seekTarget(offset int64, whence int, current, bitsPerUnit int64) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += current
+	case io.SeekEnd:
+		offset += (w.BitLen() + bitsPerUnit - 1) / bitsPerUnit
+	default:
+		return 0, ErrInvalidWhence
+	}
+
+	if offset < 0 {
+		return 0, ErrNegativeOffset
+	}
+
+	return offset, nil
+}
+
+// seekBitOffset moves the write position to the given bit offset from the start of the stream
+func (w *Writer) seekBitOffset(offset int64) error {
+	if offset < w.flushed*bitsPerByte {
+		return ErrNotSeekable
+	}
+
+	w.back = w.BitLen() - offset
+
+	return nil
+}
+
+// WriteBitsAt writes the given Bits at the given bit offset from the start of the stream,
+// overwriting or extending what was written, as SeekBit describes. The write position is unchanged.
+func (w *Writer) WriteBitsAt(offset int64, b Bits) (bitsWritten int, err error) {
+	position := w.BitOffset()
+
+	if _, err := w.SeekBit(offset, io.SeekStart); err != nil {
+		return 0, err
+	}
+
+	bitsWritten, err = w.WriteBits(b)
+
+	w.back = w.BitLen() - position
+
+	return bitsWritten, err
+}
+
+// WriteAt writes the given bytes at the given byte offset from the start of the stream,
+// as WriteBitsAt does, yielding the number of whole bytes written. It implements io.WriterAt.
+func (w *Writer) WriteAt(p []byte, offset int64) (n int, err error) {
+	position := w.BitOffset()
+
+	if _, err := w.SeekBit(offset*bitsPerByte, io.SeekStart); err != nil {
+		return 0, err
+	}
+
+	bitsWritten, err := w.WriteBytes(p)
+
+	w.back = w.BitLen() - position
+
+	return bitsWritten / bitsPerByte, err
+}
+
+// Close pads and flushes the Writer, yielding any error from the io.Writer given to NewWriterTo.
+// It does not close that io.Writer. Any write after Close fails with ErrClosed.
+//
+// Close fails with an error wrapping ErrUnfilledPlaceholder, and leaves the Writer open,
+// while any placeholder from Reserve is not filled.
+func (w *Writer) Close() error {
+	if len(w.reserved) > 0 && w.err == nil {
+		return fmt.Errorf("can not close with %v placeholders to fill: %w", len(w.reserved), ErrUnfilledPlaceholder)
+	}
+
+	err := w.Flush(true)
+
+	if w.err == nil {
+		w.err = ErrClosed
+	}
+
+	return err
+}
+
+// flushBytes writes the whole bytes to the sink, up to the first placeholder which is not
+// filled yet, keeping any that were not written
+func (w *Writer) flushBytes() error {
+	numBytes := int64(len(w.bytes))
+
+	for _, p := range w.reserved {
+		if idx := p.offset/bitsPerByte - w.flushed; idx < numBytes {
+			numBytes = idx
+		}
+	}
+
+	// the bits at the write position may still be overwritten
+	if idx := w.BitOffset()/bitsPerByte - w.flushed; w.back > 0 && idx < numBytes {
+		numBytes = idx
+	}
+
+	if numBytes == 0 {
+		return nil
+	}
+
+	n, err := w.sink.Write(w.bytes[:numBytes])
+	if err == nil && int64(n) < numBytes {
+		err = io.ErrShortWrite
+	}
+
+	w.flushed += int64(n)
+	w.bytes = w.bytes[:copy(w.bytes, w.bytes[n:])]
+
+	if err != nil {
+		w.err = err
+	}
+
+	return err
+}
+
+
+// SetLittleEndian makes the Writer write bits into the current byte from least-significant to most-significant.
+// This is the default.
+func (w *Writer) SetLittleEndian() *Writer {
+	w.endianness = LittleEndian
+	return w
+}
+
+// SetBigEndian makes the Writer write bits into the current byte from most-significant to least-significant.
+// The bits can be read back by a Reader with the same bit order, see Reader.SetBigEndian.
+//
+// NOTE: changing the bit order in the middle of a byte only affects the bits which are written after it.
+func (w *Writer) SetBigEndian() *Writer {
+	w.endianness = BigEndian
+	return w
+}
+
+// SetByteOrder sets the order of the bytes of the integers written by WriteUint, WriteInt and
+// Write, when they are a whole number of bytes wide. LittleEndian, the default, writes the
+// least-significant byte first, and BigEndian the most-significant byte first. Integers of
+// other widths are always written least-significant bit first, and bytes written by WriteBytes
+// are written in the order given.
+//
+// The byte order is independent of the bit order, which only arranges the bits within each
+// byte, see SetBigEndian. Either way, the integers can be written at any bit offset, and read
+// back by a Reader with the same bit and byte order.
+func (w *Writer) SetByteOrder(order endianness) *Writer {
+	w.byteOrder = order
+	return w
+}
+
+// Reader returns a Reader over exactly the bits written, with the same bit and byte order as the Writer,
+// so that reading past the last bit written yields io.EOF instead of padding bits. For a Writer
+// created by NewWriterTo, it covers only the bits which have not been flushed yet.
+//
+// The Reader shares the Writer's buffer instead of copying it, so it is only valid
+// until the next change to the Writer.
+func (w *Writer) Reader() *Reader {
+	r := ReaderFromBytes(w.view()...)
+
+	r.limit = w.BitLen() - w.flushed*bitsPerByte
+	r.Options.endianness = w.endianness
+	r.Options.byteOrder = w.byteOrder
+
+	return r
 }
 
+// Bits returns a copy of exactly the bits written, unlike Bytes, which pads the last byte.
+// For a Writer created by NewWriterTo, this is only the bits which have not been flushed yet.
+func (w *Writer) Bits() Bits {
+	r := w.Reader()
+
+	return r.Next(int(r.limit)).Bits().Bits
+}
+
+// view returns the bytes written, including those of the bitBuffer, without copying the bytes
+// slice. The bytes of the bitBuffer are placed in its spare capacity, where they are appended
+// once the bitBuffer is full.
+func (w *Writer) view() []byte {
+	numBytes := (w.bitOffset + bitsPerByte - 1) / bitsPerByte
+
+	w.Grow(0)
 
-// Bytes returns a copy of the byte buffer
+	data := w.bytes[:len(w.bytes)+numBytes]
+
+	for idx := 0; idx < numBytes; idx++ {
+		data[len(w.bytes)+idx] = byte(w.bitBuffer >> uint(idx*bitsPerByte))
+	}
+
+	return data
+}
+
+// Bytes returns a copy of the byte buffer. For a Writer created by NewWriterTo,
+// this is only the bytes which have not been flushed yet. A partially written last byte
+// is padded with zero bits; see BitLen, Bits and Reader for the exact number of bits.
 func (w *Writer) Bytes() []byte {
 	bytes := append([]byte{}, w.bytes...)
 
-	if w.bitOffset != 0 {
-		bytes = append(bytes, w.bitBuffer)
+	for idx := 0; idx < w.bitOffset; idx += bitsPerByte {
+		bytes = append(bytes, byte(w.bitBuffer>>uint(idx)))
 	}
 
 	return bytes
 }
 
-// Write the given args, yielding the number of bits written.
+// Write the given args in order, yielding the number of bits written.
+//
+// The arguments can be:
+//   - bool, Bits, []bool or BitVector, written bit by bit
+//   - any integer type, written at its full width as WriteUint or WriteInt would;
+//     int and uint are written as 64 bits on every platform
+//   - float32 and float64, written as their IEEE 754 bits
+//   - string and []byte, written byte by byte, with no length or terminator
+//   - a slice or array of any of these, written element by element
+//   - a BitMarshaler, which writes itself
 //
-// NOTE: the arguments can be bool, byte, []byte, or Bits
+// Any other argument yields an error wrapping ErrUnsupportedType. Writing stops at the first
+// argument which fails, and the error is an *ArgumentError holding its index.
 func (w *Writer) Write(args ...interface{}) (bitsWritten int, err error) {
 	for idx := range args {
-		switch v := args[idx].(type) {
-		case byte :
-			if num, err := w.WriteByte(v); err != nil {
-				break
-			} else {
-				bitsWritten += num
-			}
-		case []byte :
-			if num, err := w.WriteBytes(v); err != nil {
-				break
-			} else {
-				bitsWritten += num
-			}
-		case Bits:
-			if num, err := w.WriteBits(v); err != nil {
-				break
-			} else {
-				bitsWritten += num
-			}
-		case bool:
-			if num, err := w.WriteBit(v); err != nil {
-				break
-			} else {
-				bitsWritten += num
-			}
-		default:
-			err = fmt.Errorf("bad type supplied for argument, index %v, value %v", idx, v)
+		numWritten, err := w.writeValue(args[idx])
+
+		bitsWritten += numWritten
+
+		if err != nil {
+			return bitsWritten, &ArgumentError{Index: idx, Err: err}
 		}
 	}
 
-	return bitsWritten, err
+	return bitsWritten, nil
 }
 
 // WriteBytes writes the given bytes
 func (w *Writer) WriteBytes(b []byte) (bitsWritten int, err error) {
-	for idx := range b {
-		numWritten, err := w.WriteByte(b[idx])
-
-		bitsWritten += numWritten
+	for len(b) > 0 && err == nil {
+		var numWritten int
 
-		if err != nil {
-			break
+		if len(b) >= bytesPerWord {
+			numWritten, err = w.writeWord(binary.LittleEndian.Uint64(b), bitsPerWord)
+			b = b[bytesPerWord:]
+		} else {
+			numWritten, err = w.writeWord(uint64(b[0]), bitsPerByte)
+			b = b[1:]
 		}
+
+		bitsWritten += numWritten
 	}
 
 	return bitsWritten, err
@@ -89,21 +491,30 @@ func (w *Writer) WriteBytes(b []byte) (bitsWritten int, err error) {
 
 // WriteByte writes the given byte
 func (w *Writer) WriteByte(b byte) (bitsWritten int, err error) {
-	bits := NewReader().FromBytes(b).Next(1).Bytes().Bits
-
-	return w.WriteBits(bits)
+	return w.writeWord(uint64(b), bitsPerByte)
 }
 
 // WriteBits writes the given Bits
 func (w *Writer) WriteBits(b Bits) (bitsWritten int, err error) {
-	for idx := range b {
-		numWritten, err := w.WriteBit(b[idx])
+	for idx := 0; idx < len(b) && err == nil; idx += bitsPerWord {
+		chunk := b[idx:]
+		if len(chunk) > bitsPerWord {
+			chunk = chunk[:bitsPerWord]
+		}
 
-		bitsWritten += numWritten
+		word := uint64(0)
 
-		if err != nil {
-			break
+		for bitIdx := range chunk {
+			if chunk[bitIdx] {
+				word |= 1 << uint(bitIdx)
+			}
 		}
+
+		var numWritten int
+
+		numWritten, err = w.writeWord(word, len(chunk))
+
+		bitsWritten += numWritten
 	}
 
 	return bitsWritten, err
@@ -111,29 +522,188 @@ func (w *Writer) WriteBits(b Bits) (bitsWritten int, err error) {
 
 // WriteBit writes the given bit
 func (w *Writer) WriteBit(b bool) (bitsWritten int, err error) {
-	shift := uint8(0)
-	bp := uint8(w.bitOffset)
+	v := uint64(0)
+	if b {
+		v = 1
+	}
 
-	switch w.endianness {
-	case LittleEndian:
-		shift = bp
-	case BigEndian:
-		shift = uint8(bitsPerByte) - bp - 1
+	// fast path, the bit fits in the bitBuffer without filling it
+	if w.err == nil && w.back == 0 && w.bitOffset < bitsPerWord-1 {
+		w.bitBuffer |= v << bitShift(w.bitOffset%bitsPerByte, w.endianness) << uint(w.bitOffset/bitsPerByte*bitsPerByte)
+		w.bitOffset++
+
+		return 1, nil
 	}
 
-	w.bitOffset++
+	return w.writeWord(v, 1)
+}
 
-	if b {
-		w.bitBuffer |= 1<<shift
+// bitShift yields the shift of bit index bp of a byte, in the given bit order
+func bitShift(bp int, order endianness) uint8 {
+	if order == BigEndian {
+		return uint8(bitsPerByte - bp - 1)
 	}
 
-	if w.bitOffset >= bitsPerByte {
-		w.bitOffset = 0
-		w.bytes = append(w.bytes, w.bitBuffer)
-		w.bitBuffer = 0
+	return uint8(bp)
+}
+
+// WriteUint writes the nBits least-significant bits of v, least-significant first,
+// so that the bits read back as v with Bits.AsUInt64. A whole number of bytes is written
+// in the byte order of the Writer, see SetByteOrder. The width can be up to 64 bits;
+// an error wrapping ErrOverflow is returned, and nothing is written, if v does not fit.
+func (w *Writer) WriteUint(v uint64, nBits int) (bitsWritten int, err error) {
+	if nBits < 0 || nBits > bitsPerWord {
+		return 0, fmt.Errorf("can not write %v bits: %w", nBits, ErrInvalidWidth)
 	}
 
-	return 1, err
+	if nBits < bitsPerWord && v>>uint(nBits) != 0 {
+		return 0, fmt.Errorf("can not write %v as a %v-bit unsigned integer: %w", v, nBits, ErrOverflow)
+	}
+
+	return w.writeWord(orderBytes(v, nBits, w.byteOrder), nBits)
+}
+
+// WriteInt writes v as an nBits wide two's complement integer, the counterpart of Bits.AsInt64.
+// As for WriteUint, a whole number of bytes is written in the byte order of the Writer. The width
+// can be up to 64 bits; an error wrapping ErrOverflow is returned, and nothing is written, if v
+// does not fit.
+func (w *Writer) WriteInt(v int64, nBits int) (bitsWritten int, err error) {
+	if nBits < 0 || nBits > bitsPerWord {
+		return 0, fmt.Errorf("can not write %v bits: %w", nBits, ErrInvalidWidth)
+	}
+
+	// the bits above the sign bit must all be copies of it
+	if nBits == 0 && v != 0 || nBits > 0 && nBits < bitsPerWord && v>>uint(nBits-1) != v>>(bitsPerWord-1) {
+		return 0, fmt.Errorf("can not write %v as a %v-bit signed integer: %w", v, nBits, ErrOverflow)
+	}
+
+	return w.writeWord(orderBytes(uint64(v), nBits, w.byteOrder), nBits)
+}
+
+// orderBytes arranges the bytes of an nBits wide integer so that writing it least-significant
+// bit first puts its bytes in the given byte order. Widths which are not whole bytes are unaffected.
+func orderBytes(v uint64, nBits int, order endianness) uint64 {
+	if order != BigEndian || nBits == 0 || nBits%bitsPerByte != 0 {
+		return v
+	}
+
+	return bits.ReverseBytes64(v) >> uint(bitsPerWord-nBits)
+}
+
+// writeWord writes the nBits least-significant bits of v, least-significant first.
+// Every write goes through here, so that whole words are added to the bitBuffer at once.
+func (w *Writer) writeWord(v uint64, nBits int) (bitsWritten int, err error) {
+	if w.err != nil {
+		return 0, w.err
+	}
+
+	if w.back != 0 {
+		return w.writeWordAt(v, nBits)
+	}
+
+	if nBits < bitsPerWord {
+		v &= (1 << uint(nBits)) - 1
+	}
+
+	free := bitsPerWord - w.bitOffset
+
+	w.bitBuffer |= w.arrange(v << uint(w.bitOffset))
+
+	if nBits < free {
+		w.bitOffset += nBits
+		return nBits, nil
+	}
+
+	// the bitBuffer is full, so it is appended as a whole word, keeping the rest of v
+	w.bytes = append(w.bytes, 0, 0, 0, 0, 0, 0, 0, 0)
+	binary.LittleEndian.PutUint64(w.bytes[len(w.bytes)-bytesPerWord:], w.bitBuffer)
+
+	w.bitBuffer = w.arrange(v >> uint(free))
+	w.bitOffset = nBits - free
+
+	if w.sink != nil && len(w.bytes) >= writeChunkSize {
+		err = w.flushBytes()
+	}
+
+	return nBits, err
+}
+
+// writeWordAt writes as writeWord does, at a write position away from the end, see SeekBit
+func (w *Writer) writeWordAt(v uint64, nBits int) (bitsWritten int, err error) {
+	if w.back < 0 {
+		// seeked past the end, so the gap is filled with zeros first
+		gap := -w.back
+		w.back = 0
+
+		for ; gap > 0; gap -= bitsPerWord {
+			numBits := bitsPerWord
+			if gap < bitsPerWord {
+				numBits = int(gap)
+			}
+
+			if _, err := w.writeWord(0, numBits); err != nil {
+				return 0, err
+			}
+		}
+
+		return w.writeWord(v, nBits)
+	}
+
+	for ; bitsWritten < nBits; bitsWritten++ {
+		if w.back == 0 {
+			// the rest extends the written bits
+			numWritten, err := w.writeWord(v>>uint(bitsWritten), nBits-bitsWritten)
+			return bitsWritten + numWritten, err
+		}
+
+		w.setBit(w.BitLen()-w.back, (v>>uint(bitsWritten))&1 == 1, w.endianness)
+		w.back--
+	}
+
+	return bitsWritten, nil
+}
+
+// arrange moves bits from the order they are written in, to their place in the little-endian
+// bytes of a word, which depends on the bit order. See bitShift.
+func (w *Writer) arrange(v uint64) uint64 {
+	if w.endianness == BigEndian {
+		// reversing the whole word and then its bytes reverses the bits within each byte
+		return bits.ReverseBytes64(bits.Reverse64(v))
+	}
+
+	return v
+}
+
+// spill appends the whole bytes of the bitBuffer to the bytes slice
+func (w *Writer) spill() {
+	for ; w.bitOffset >= bitsPerByte; w.bitOffset -= bitsPerByte {
+		w.bytes = append(w.bytes, byte(w.bitBuffer))
+		w.bitBuffer >>= bitsPerByte
+	}
+}
+
+// setBit overwrites the bit at the given offset from the start of the stream, which must not be flushed
+func (w *Writer) setBit(offset int64, b bool, order endianness) {
+	if idx := offset/bitsPerByte - w.flushed; idx < int64(len(w.bytes)) {
+		mask := byte(1) << bitShift(int(offset%bitsPerByte), order)
+
+		if b {
+			w.bytes[idx] |= mask
+		} else {
+			w.bytes[idx] &^= mask
+		}
+
+		return
+	}
+
+	bp := int(offset - (w.flushed+int64(len(w.bytes)))*bitsPerByte)
+	mask := uint64(1) << (uint(bp/bitsPerByte*bitsPerByte) + uint(bitShift(bp%bitsPerByte, order)))
+
+	if b {
+		w.bitBuffer |= mask
+	} else {
+		w.bitBuffer &^= mask
+	}
 }
 
 // WriteBool writes the given Bits, an alias for WriteBit
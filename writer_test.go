@@ -1,14 +1,20 @@
 package bitstream
 
 import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"math/rand"
 	"reflect"
+	"sync"
 	"testing"
 )
 
 func TestWriter_Bytes(t *testing.T) {
 	type fields struct {
 		bytes       []byte
-		currentByte byte
+		currentByte uint64
 		bitOffset   int
 	}
 	tests := []struct {
@@ -67,7 +73,7 @@ func TestWriter_WriteBit(t *testing.T) {
 func TestWriter_WriteBits(t *testing.T) {
 	type state struct {
 		bytes       []byte
-		currentByte byte
+		currentByte uint64
 		bitOffset   int
 	}
 	tests := []struct {
@@ -160,7 +166,7 @@ func TestWriter_WriteBool(t *testing.T) {
 func TestWriter_WriteByte(t *testing.T) {
 	type state struct {
 		existingBytes []byte
-		bitBuffer     byte
+		bitBuffer     uint64
 		bitOffset     int
 		endianness
 	}
@@ -232,7 +238,7 @@ func TestWriter_WriteByte(t *testing.T) {
 func TestWriter_WriteBytes(t *testing.T) {
 	type state struct {
 		existingBytes []byte
-		bitBuffer     byte
+		bitBuffer     uint64
 		bitOffset     int
 		endianness
 	}
@@ -298,7 +304,7 @@ func TestWriter_WriteBytes(t *testing.T) {
 func TestWriter_Write(t *testing.T) {
 	type fields struct {
 		bytes      []byte
-		bitBuffer  byte
+		bitBuffer  uint64
 		bitOffset  int
 		endianness endianness
 	}
@@ -328,23 +334,37 @@ func TestWriter_Write(t *testing.T) {
 			[]byte{0b_0000_0110, 0b_1010_1010, 0b_0110_0110, 0b_0001_1110, 0},
 			false},
 		{
-			"bad arg string",
+			"string",
 			fields{},
 			args{args: []interface{}{"123"}},
+			24,
+			[]byte{'1', '2', '3'},
+			false},
+		{
+			"int64",
+			fields{},
+			args{args: []interface{}{int64(-2)}},
+			64,
+			[]byte{0xFE, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF},
+			false},
+		{
+			"bad arg map",
+			fields{},
+			args{args: []interface{}{map[string]int{}}},
 			0,
 			nil,
 			true},
 		{
-			"bad arg int64",
+			"bad arg complex128",
 			fields{},
-			args{args: []interface{}{int64(1234567)}},
+			args{args: []interface{}{complex(1, 2)}},
 			0,
 			nil,
 			true},
 		{
 			"mixed with bad args",
 			fields{},
-			args{args: []interface{}{F, T, F, int64(1234567)}},
+			args{args: []interface{}{F, T, F, complex(1, 2), T}},
 			3,
 			[]byte{2},
 			true},
@@ -376,3 +396,1029 @@ func TestWriter_Write(t *testing.T) {
 		})
 	}
 }
+
+func TestWriter_Flush(t *testing.T) {
+	tests := []struct {
+		name           string
+		bitsToWrite    Bits
+		pad            bool
+		expectedSink   []byte
+		expectedBuffer []byte
+	}{
+		{"empty", Bits{}, true, []byte{}, []byte{}},
+		{"aligned", BitsFromByte(0xAB), false, []byte{0xAB}, []byte{}},
+		{"unaligned, no pad", Bits{T, T, T, T, T, T, T, T, F, T}, false, []byte{0xFF}, []byte{0b10}},
+		{"unaligned, pad", Bits{T, T, T, T, T, T, T, T, F, T}, true, []byte{0xFF, 0b10}, []byte{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := &bytes.Buffer{}
+			w := NewWriterTo(sink)
+
+			if _, err := w.WriteBits(tt.bitsToWrite); err != nil {
+				t.Errorf("WriteBits() error = %v", err)
+				return
+			}
+
+			if err := w.Flush(tt.pad); err != nil {
+				t.Errorf("Flush() error = %v", err)
+				return
+			}
+
+			if got := sink.Bytes(); !bytes.Equal(got, tt.expectedSink) {
+				t.Errorf("flushed bytes = %v, want %v", got, tt.expectedSink)
+			}
+
+			if got := w.Bytes(); !bytes.Equal(got, tt.expectedBuffer) {
+				t.Errorf("Bytes() = %v, want %v", got, tt.expectedBuffer)
+			}
+		})
+	}
+}
+
+func TestNewWriterTo(t *testing.T) {
+	data := make([]byte, writeChunkSize*3+5)
+	for idx := range data {
+		data[idx] = byte(idx * 7)
+	}
+
+	sink := &bytes.Buffer{}
+	w := NewWriterTo(sink)
+
+	if _, err := w.WriteBit(T); err != nil {
+		t.Fatalf("WriteBit() error = %v", err)
+	}
+
+	bitsWritten, err := w.WriteBytes(data)
+	if err != nil {
+		t.Fatalf("WriteBytes() error = %v", err)
+	}
+
+	if bitsWritten != len(data)*bitsPerByte {
+		t.Errorf("WriteBytes() gotBitsWritten = %v, want %v", bitsWritten, len(data)*bitsPerByte)
+	}
+
+	if sink.Len() < writeChunkSize*3 || len(w.Bytes()) > writeChunkSize+1 {
+		t.Errorf("expected whole bytes to be flushed as they fill, flushed %v, buffered %v",
+			sink.Len(), len(w.Bytes()))
+	}
+
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	expected := &Writer{}
+	_, _ = expected.WriteBit(T)
+	_, _ = expected.WriteBytes(data)
+
+	if got, want := sink.Bytes(), expected.Bytes(); !bytes.Equal(got, want) {
+		t.Errorf("streamed bytes differ from in-memory bytes")
+	}
+
+	if _, err := w.WriteBit(T); !errors.Is(err, ErrClosed) {
+		t.Errorf("WriteBit() after Close() error = %v, want %v", err, ErrClosed)
+	}
+}
+
+type failingWriter struct {
+	limit int
+}
+
+func (f *failingWriter) Write(p []byte) (int, error) {
+	if len(p) > f.limit {
+		n := f.limit
+		f.limit = 0
+
+		return n, io.ErrClosedPipe
+	}
+
+	f.limit -= len(p)
+
+	return len(p), nil
+}
+
+func TestWriter_SinkError(t *testing.T) {
+	w := NewWriterTo(&failingWriter{limit: 10})
+
+	bitsWritten, err := w.WriteBytes(make([]byte, writeChunkSize*2))
+	if !errors.Is(err, io.ErrClosedPipe) {
+		t.Errorf("WriteBytes() error = %v, want %v", err, io.ErrClosedPipe)
+	}
+
+	if bitsWritten != writeChunkSize*bitsPerByte {
+		t.Errorf("WriteBytes() gotBitsWritten = %v, want %v", bitsWritten, writeChunkSize*bitsPerByte)
+	}
+
+	if got := len(w.Bytes()); got != writeChunkSize-10 {
+		t.Errorf("expected the unwritten bytes to stay buffered, got %v", got)
+	}
+
+	if bitsWritten, err := w.WriteBit(T); bitsWritten != 0 || !errors.Is(err, io.ErrClosedPipe) {
+		t.Errorf("WriteBit() = %v, %v, want 0, %v", bitsWritten, err, io.ErrClosedPipe)
+	}
+
+	if err := w.Close(); !errors.Is(err, io.ErrClosedPipe) {
+		t.Errorf("Close() error = %v, want %v", err, io.ErrClosedPipe)
+	}
+}
+
+func TestWriter_BitOrder(t *testing.T) {
+	tests := []struct {
+		name          string
+		bigEndian     bool
+		bitsToWrite   Bits
+		expectedBytes []byte
+	}{
+		{"little endian, first bit", false, Bits{T}, []byte{0b_0000_0001}},
+		{"little endian, partial", false, Bits{T, F, T, T}, []byte{0b_0000_1101}},
+		{"little endian, unaligned", false, Bits{F, F, F, F, F, F, F, T, T}, []byte{0b_1000_0000, 0b_0000_0001}},
+		{"big endian, first bit", true, Bits{T}, []byte{0b_1000_0000}},
+		{"big endian, partial", true, Bits{T, F, T, T}, []byte{0b_1011_0000}},
+		{"big endian, unaligned", true, Bits{F, F, F, F, F, F, F, T, T}, []byte{0b_0000_0001, 0b_1000_0000}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := (&Writer{}).SetLittleEndian()
+			r := NewReader().FromBytes(tt.expectedBytes...).SetLittleEndian()
+
+			if tt.bigEndian {
+				w.SetBigEndian()
+				r.SetBigEndian()
+			}
+
+			if _, err := w.WriteBits(tt.bitsToWrite); err != nil {
+				t.Errorf("WriteBits() error = %v", err)
+				return
+			}
+
+			if got := w.Bytes(); !reflect.DeepEqual(got, tt.expectedBytes) {
+				t.Errorf("Bytes() = %v, want %v", got, tt.expectedBytes)
+			}
+
+			if got := r.Next(len(tt.bitsToWrite)).Bits(); !reflect.DeepEqual(got.Bits, tt.bitsToWrite) {
+				t.Errorf("Bits() = %v, want %v", got.Bits, tt.bitsToWrite)
+			}
+		})
+	}
+}
+
+func TestWriter_BitOrderChange(t *testing.T) {
+	w := &Writer{}
+
+	_, _ = w.WriteBits(Bits{T, T})
+	_, _ = w.SetBigEndian().WriteBits(Bits{T, F, F, F, F, F, F, F, T})
+
+	if got, want := w.Bytes(), []byte{0b_0010_0011, 0b_0010_0000}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Bytes() = %08b, want %08b", got, want)
+	}
+}
+
+func TestWriter_ByteOrder(t *testing.T) {
+	tests := []struct {
+		name          string
+		bitOrder      endianness
+		byteOrder     endianness
+		expectedBytes []byte
+	}{
+		{"little endian bits and bytes", LittleEndian, LittleEndian, []byte{0x78, 0x56, 0x34, 0x12}},
+		{"little endian bits, big endian bytes", LittleEndian, BigEndian, []byte{0x12, 0x34, 0x56, 0x78}},
+		// the bits of each byte are written from the most-significant end, so they are reversed
+		{"big endian bits, little endian bytes", BigEndian, LittleEndian, []byte{0x1E, 0x6A, 0x2C, 0x48}},
+		{"big endian bits and bytes", BigEndian, BigEndian, []byte{0x48, 0x2C, 0x6A, 0x1E}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := (&Writer{}).SetByteOrder(tt.byteOrder)
+			if tt.bitOrder == BigEndian {
+				w.SetBigEndian()
+			}
+
+			if _, err := w.WriteUint(0x12345678, 32); err != nil {
+				t.Fatalf("WriteUint() error = %v", err)
+			}
+
+			if got := w.Bytes(); !reflect.DeepEqual(got, tt.expectedBytes) {
+				t.Errorf("Bytes() = %#x, want %#x", got, tt.expectedBytes)
+			}
+
+			// at every bit offset, each width reads back with the same bit and byte order
+			for offset := 0; offset < bitsPerByte; offset++ {
+				w.Reset()
+
+				_, _ = w.WriteUint(0, offset)
+				_, _ = w.WriteUint(0xBEEF, 16)
+				_, _ = w.WriteInt(-0x12345678, 32)
+				_, _ = w.Write(uint64(0x0123456789ABCDEF))
+				_, _ = w.WriteUint(0x5, 3)
+				_, _ = w.WriteBytes([]byte("abcd"))
+
+				r := w.Reader()
+				_ = r.Next(offset).Bits()
+
+				if got, err := r.Next(2).Bytes().AsUInt16(); err != nil || got != 0xBEEF {
+					t.Errorf("offset %v: AsUInt16() = %#x, %v", offset, got, err)
+				}
+
+				if got, err := r.Next(4).Bytes().AsInt32(); err != nil || got != -0x12345678 {
+					t.Errorf("offset %v: AsInt32() = %#x, %v", offset, got, err)
+				}
+
+				if got, err := r.Next(8).Bytes().AsUInt64(); err != nil || got != 0x0123456789ABCDEF {
+					t.Errorf("offset %v: AsUInt64() = %#x, %v", offset, got, err)
+				}
+
+				// widths which are not whole bytes are not reordered
+				if got, err := r.Next(3).Bits().AsUInt(); err != nil || got != 0x5 {
+					t.Errorf("offset %v: AsUInt() = %#x, %v", offset, got, err)
+				}
+
+				// nor are raw bytes
+				if got, err := r.Next(4).Bytes().AsBytes(); err != nil || string(got) != "abcd" {
+					t.Errorf("offset %v: AsBytes() = %q, %v", offset, got, err)
+				}
+			}
+		})
+	}
+}
+
+func TestWriter_ByteOrderPlaceholder(t *testing.T) {
+	w := (&Writer{}).SetByteOrder(BigEndian)
+
+	p, _ := w.Reserve(16)
+	_, _ = w.WriteByte(0xFF)
+
+	// the byte order when the bits were reserved applies
+	w.SetByteOrder(LittleEndian)
+
+	if err := p.FillUint(0x1234); err != nil {
+		t.Fatalf("FillUint() error = %v", err)
+	}
+
+	if got, want := w.Bytes(), []byte{0x12, 0x34, 0xFF}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Bytes() = %#x, want %#x", got, want)
+	}
+}
+
+func TestWriter_RoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	for _, bigEndian := range []bool{false, true} {
+		name := "little endian"
+		if bigEndian {
+			name = "big endian"
+		}
+
+		t.Run(name, func(t *testing.T) {
+			var written []interface{}
+
+			w := &Writer{}
+			if bigEndian {
+				w.SetBigEndian()
+			}
+
+			// a random mix of bit runs of every length and whole bytes, at every alignment
+			for idx := 0; idx < 1000; idx++ {
+				if rng.Intn(3) == 0 {
+					b := byte(rng.Intn(256))
+					written = append(written, b)
+
+					if _, err := w.WriteByte(b); err != nil {
+						t.Fatalf("WriteByte() error = %v", err)
+					}
+
+					continue
+				}
+
+				bits := make(Bits, 1+rng.Intn(70))
+				for bitIdx := range bits {
+					bits[bitIdx] = rng.Intn(2) == 1
+				}
+
+				written = append(written, bits)
+
+				if _, err := w.WriteBits(bits); err != nil {
+					t.Fatalf("WriteBits() error = %v", err)
+				}
+			}
+
+			r := NewReader().FromBytes(w.Bytes()...)
+			if bigEndian {
+				r.SetBigEndian()
+			}
+
+			for idx, v := range written {
+				switch v := v.(type) {
+				case byte:
+					if got, err := r.Next(1).Bytes().AsByte(); err != nil || got != v {
+						t.Fatalf("value %v: AsByte() = %v, %v, want %v", idx, got, err, v)
+					}
+				case Bits:
+					if got := r.Next(len(v)).Bits(); got.Error != nil || !reflect.DeepEqual(got.Bits, v) {
+						t.Fatalf("value %v: Bits() = %v, %v, want %v", idx, got.Bits, got.Error, v)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestWriter_WriteUint(t *testing.T) {
+	tests := []struct {
+		name          string
+		value         uint64
+		nBits         int
+		expectedBytes []byte
+		wantErr       error
+	}{
+		{"zero width", 0, 0, []byte{}, nil},
+		{"6 bits", 0b_10_1101, 6, []byte{0b_0010_1101}, nil},
+		{"12 bits", 0xABC, 12, []byte{0xBC, 0x0A}, nil},
+		{"64 bits", 0xFEDCBA9876543210, 64, []byte{0x10, 0x32, 0x54, 0x76, 0x98, 0xBA, 0xDC, 0xFE}, nil},
+		{"overflow", 0b_100_0000, 6, []byte{}, ErrOverflow},
+		{"overflow zero width", 1, 0, []byte{}, ErrOverflow},
+		{"negative width", 0, -1, []byte{}, ErrInvalidWidth},
+		{"too wide", 0, 65, []byte{}, ErrInvalidWidth},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &Writer{}
+
+			gotBitsWritten, err := w.WriteUint(tt.value, tt.nBits)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("WriteUint() error = %v, want %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErr == nil && gotBitsWritten != tt.nBits {
+				t.Errorf("WriteUint() gotBitsWritten = %v, want %v", gotBitsWritten, tt.nBits)
+			}
+
+			if got := w.Bytes(); !reflect.DeepEqual(got, tt.expectedBytes) {
+				t.Errorf("Bytes() = %v, want %v", got, tt.expectedBytes)
+			}
+		})
+	}
+}
+
+func TestWriter_WriteInt(t *testing.T) {
+	tests := []struct {
+		name          string
+		value         int64
+		nBits         int
+		expectedBytes []byte
+		wantErr       error
+	}{
+		{"1 bit, -1", -1, 1, []byte{0b1}, nil},
+		{"6 bits, positive", 31, 6, []byte{0b_0001_1111}, nil},
+		{"6 bits, negative", -32, 6, []byte{0b_0010_0000}, nil},
+		{"12 bits, negative", -2, 12, []byte{0xFE, 0x0F}, nil},
+		{"64 bits, min", -1 << 63, 64, []byte{0, 0, 0, 0, 0, 0, 0, 0x80}, nil},
+		{"overflow positive", 32, 6, []byte{}, ErrOverflow},
+		{"overflow negative", -33, 6, []byte{}, ErrOverflow},
+		{"overflow 1 bit", 1, 1, []byte{}, ErrOverflow},
+		{"overflow zero width", -1, 0, []byte{}, ErrOverflow},
+		{"too wide", 0, 65, []byte{}, ErrInvalidWidth},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &Writer{}
+
+			gotBitsWritten, err := w.WriteInt(tt.value, tt.nBits)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("WriteInt() error = %v, want %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErr == nil && gotBitsWritten != tt.nBits {
+				t.Errorf("WriteInt() gotBitsWritten = %v, want %v", gotBitsWritten, tt.nBits)
+			}
+
+			if got := w.Bytes(); !reflect.DeepEqual(got, tt.expectedBytes) {
+				t.Errorf("Bytes() = %v, want %v", got, tt.expectedBytes)
+			}
+		})
+	}
+}
+
+func TestWriter_WriteIntRoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	for nBits := 1; nBits <= 64; nBits++ {
+		w := &Writer{}
+
+		unsigned := rng.Uint64() >> uint(64-nBits)
+		signed := int64(rng.Uint64()) >> uint(64-nBits)
+
+		if _, err := w.WriteUint(unsigned, nBits); err != nil {
+			t.Fatalf("WriteUint(%v, %v) error = %v", unsigned, nBits, err)
+		}
+
+		if _, err := w.WriteInt(signed, nBits); err != nil {
+			t.Fatalf("WriteInt(%v, %v) error = %v", signed, nBits, err)
+		}
+
+		r := NewReader().FromBytes(w.Bytes()...)
+
+		if got := r.Next(nBits).Bits().Bits.AsUInt64(); got != unsigned {
+			t.Errorf("%v bits: AsUInt64() = %v, want %v", nBits, got, unsigned)
+		}
+
+		if got := r.Next(nBits).Bits().Bits.AsInt64(); got != signed {
+			t.Errorf("%v bits: AsInt64() = %v, want %v", nBits, got, signed)
+		}
+	}
+}
+
+var (
+	_ io.WriterAt = &Writer{}
+	_ io.Seeker   = &Writer{}
+)
+
+func TestWriter_SeekBit(t *testing.T) {
+	tests := []struct {
+		name          string
+		offset        int64
+		whence        int
+		bitsToWrite   Bits
+		wantOffset    int64
+		expectedBytes []byte
+		wantErr       error
+	}{
+		{"start, overwrite", 3, io.SeekStart, Bits{T, T}, 3, []byte{0b_0001_1000, 0xFF}, nil},
+		{"current, overwrite", -2, io.SeekCurrent, Bits{F}, 14, []byte{0, 0b_1011_1111}, nil},
+		{"end, overwrite and extend", -1, io.SeekEnd, Bits{F, T, T}, 15, []byte{0, 0b_0111_1111, 0b11}, nil},
+		{"past the end", 2, io.SeekEnd, Bits{T}, 18, []byte{0, 0xFF, 0b100}, nil},
+		{"negative", -1, io.SeekStart, nil, 0, nil, ErrNegativeOffset},
+		{"bad whence", 0, 3, nil, 0, nil, ErrInvalidWhence},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &Writer{}
+			_, _ = w.WriteBytes([]byte{0, 0xFF})
+
+			gotOffset, err := w.SeekBit(tt.offset, tt.whence)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("SeekBit() error = %v, want %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErr != nil {
+				return
+			}
+
+			if gotOffset != tt.wantOffset {
+				t.Errorf("SeekBit() = %v, want %v", gotOffset, tt.wantOffset)
+			}
+
+			if _, err := w.WriteBits(tt.bitsToWrite); err != nil {
+				t.Errorf("WriteBits() error = %v", err)
+			}
+
+			if got := w.Bytes(); !reflect.DeepEqual(got, tt.expectedBytes) {
+				t.Errorf("Bytes() = %08b, want %08b", got, tt.expectedBytes)
+			}
+		})
+	}
+}
+
+func TestWriter_WriteBitsAt(t *testing.T) {
+	w := &Writer{}
+	_, _ = w.WriteBytes([]byte{0x11, 0x22, 0x33})
+
+	if _, err := w.WriteBitsAt(12, Bits{T, T, T, T, F, F, F, F}); err != nil {
+		t.Errorf("WriteBitsAt() error = %v", err)
+	}
+
+	if n, err := w.WriteAt([]byte{0xAB, 0xCD}, 2); n != 2 || err != nil {
+		t.Errorf("WriteAt() = %v, %v, want 2, nil", n, err)
+	}
+
+	if w.BitOffset() != 24 {
+		t.Errorf("BitOffset() = %v, the write position should not move", w.BitOffset())
+	}
+
+	_, _ = w.WriteByte(0xEE)
+
+	if got, want := w.Bytes(), []byte{0x11, 0xF2, 0xAB, 0xEE}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Bytes() = %x, want %x", got, want)
+	}
+
+	if _, err := w.WriteAt([]byte{0x44}, -1); !errors.Is(err, ErrNegativeOffset) {
+		t.Errorf("WriteAt() error = %v, want %v", err, ErrNegativeOffset)
+	}
+
+	position, err := w.Seek(-1, io.SeekEnd)
+	if position != 3 || err != nil {
+		t.Errorf("Seek() = %v, %v, want 3, nil", position, err)
+	}
+
+	_, _ = w.WriteBytes([]byte{0x55, 0x66})
+
+	if got, want := w.Bytes(), []byte{0x11, 0xF2, 0xAB, 0x55, 0x66}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Bytes() = %x, want %x", got, want)
+	}
+}
+
+func TestWriter_SeekStreaming(t *testing.T) {
+	sink := &bytes.Buffer{}
+	w := NewWriterTo(sink)
+
+	_, _ = w.WriteBytes([]byte{1, 2, 3, 4})
+	_ = w.Flush(false)
+
+	_, _ = w.WriteBytes([]byte{5, 6, 7, 8})
+
+	if _, err := w.Seek(3, io.SeekStart); !errors.Is(err, ErrNotSeekable) {
+		t.Errorf("Seek() error = %v, want %v", err, ErrNotSeekable)
+	}
+
+	if _, err := w.Seek(5, io.SeekStart); err != nil {
+		t.Errorf("Seek() error = %v", err)
+	}
+
+	_ = w.Flush(false)
+
+	if sink.Len() != 5 {
+		t.Errorf("flushed %v bytes, want only the 5 before the write position", sink.Len())
+	}
+
+	_, _ = w.WriteBytes([]byte{0xFF})
+	_, _ = w.Seek(0, io.SeekEnd)
+	_, _ = w.WriteBytes([]byte{9})
+
+	if err := w.Close(); err != nil {
+		t.Errorf("Close() error = %v", err)
+	}
+
+	if got, want := sink.Bytes(), []byte{1, 2, 3, 4, 5, 0xFF, 7, 8, 9}; !reflect.DeepEqual(got, want) {
+		t.Errorf("streamed bytes = %v, want %v", got, want)
+	}
+}
+
+func TestWriter_Allocs(t *testing.T) {
+	data := make([]byte, 1000)
+	bits := Bits{T, F, T, T, F, T, T, T, F, F, F, T, T}
+
+	for _, bigEndian := range []bool{false, true} {
+		w := NewWriterTo(ioutil.Discard)
+		if bigEndian {
+			w.SetBigEndian()
+		}
+
+		allocs := testing.AllocsPerRun(100, func() {
+			_, _ = w.WriteBit(T)
+			_, _ = w.WriteByte(0xAB)
+			_, _ = w.WriteBytes(data)
+			_, _ = w.WriteBits(bits)
+			_, _ = w.WriteUint(0x1234, 13)
+			_, _ = w.WriteInt(-5, 7)
+		})
+
+		if allocs != 0 {
+			t.Errorf("big endian %v: %v allocations per run, want 0", bigEndian, allocs)
+		}
+	}
+}
+
+func TestWriter_Truncate(t *testing.T) {
+	tests := []struct {
+		name          string
+		bigEndian     bool
+		nBits         int64
+		expectedBytes []byte
+		wantErr       error
+	}{
+		{"everything", false, 0, []byte{}, nil},
+		{"nothing", false, 21, []byte{0xFF, 0x0F, 0b_1_1111}, nil},
+		{"whole bytes", false, 8, []byte{0xFF}, nil},
+		{"partial byte", false, 11, []byte{0xFF, 0b111}, nil},
+		{"within the last byte", false, 19, []byte{0xFF, 0x0F, 0b111}, nil},
+		{"big endian, partial byte", true, 11, []byte{0xFF, 0b_1110_0000}, nil},
+		{"negative", false, -1, nil, ErrInvalidWidth},
+		{"too long", false, 22, nil, ErrInvalidWidth},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &Writer{}
+			if tt.bigEndian {
+				w.SetBigEndian()
+			}
+
+			_, _ = w.WriteUint(0xFF, 8)
+			_, _ = w.WriteUint(0xF, 8)
+			_, _ = w.WriteUint(0x1F, 5)
+
+			err := w.Truncate(tt.nBits)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Truncate() error = %v, want %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErr != nil {
+				return
+			}
+
+			if got := w.Bytes(); !reflect.DeepEqual(got, tt.expectedBytes) {
+				t.Errorf("Bytes() = %08b, want %08b", got, tt.expectedBytes)
+			}
+
+			if w.BitLen() != tt.nBits || w.BitOffset() != tt.nBits {
+				t.Errorf("BitLen() = %v, BitOffset() = %v, want %v", w.BitLen(), w.BitOffset(), tt.nBits)
+			}
+
+			// writing continues from the new end
+			_, _ = w.WriteBit(T)
+
+			if w.BitLen() != tt.nBits+1 {
+				t.Errorf("BitLen() = %v, want %v", w.BitLen(), tt.nBits+1)
+			}
+		})
+	}
+}
+
+func TestWriter_TruncatePlaceholder(t *testing.T) {
+	sink := &bytes.Buffer{}
+	w := NewWriterTo(sink)
+
+	_, _ = w.WriteBytes([]byte{1, 2})
+	_ = w.Flush(false)
+
+	kept, _ := w.Reserve(8)
+	cut, _ := w.Reserve(8)
+
+	if err := w.Truncate(8); !errors.Is(err, ErrNotSeekable) {
+		t.Errorf("Truncate() error = %v, want %v", err, ErrNotSeekable)
+	}
+
+	if err := w.Truncate(30); err != nil {
+		t.Errorf("Truncate() error = %v", err)
+	}
+
+	if err := cut.FillUint(1); !errors.Is(err, ErrPlaceholderFilled) {
+		t.Errorf("FillUint() error = %v, want %v", err, ErrPlaceholderFilled)
+	}
+
+	if err := kept.FillUint(3); err != nil {
+		t.Errorf("FillUint() error = %v", err)
+	}
+
+	if err := w.Close(); err != nil {
+		t.Errorf("Close() error = %v", err)
+	}
+
+	if got, want := sink.Bytes(), []byte{1, 2, 3, 0}; !reflect.DeepEqual(got, want) {
+		t.Errorf("streamed bytes = %v, want %v", got, want)
+	}
+}
+
+func TestWriter_Reset(t *testing.T) {
+	sink := &bytes.Buffer{}
+	w := NewWriterTo(sink).SetBigEndian()
+
+	_, _ = w.WriteBytes(make([]byte, 10))
+	p, _ := w.Reserve(3)
+	_ = w.Close()
+
+	w.Reset()
+
+	if w.BitLen() != 0 || len(w.Bytes()) != 0 {
+		t.Errorf("expected an empty Writer, got %v bits", w.BitLen())
+	}
+
+	if err := p.FillUint(1); !errors.Is(err, ErrPlaceholderFilled) {
+		t.Errorf("FillUint() error = %v, want %v", err, ErrPlaceholderFilled)
+	}
+
+	// the bit order and the io.Writer are kept, and the closed Writer can be reused
+	_, _ = w.WriteBit(T)
+
+	if err := w.Close(); err != nil {
+		t.Errorf("Close() error = %v", err)
+	}
+
+	if got, want := sink.Bytes(), []byte{0x80}; !reflect.DeepEqual(got, want) {
+		t.Errorf("streamed bytes = %v, want %v", got, want)
+	}
+}
+
+func TestWriter_Grow(t *testing.T) {
+	data := make([]byte, 1000)
+	w := &Writer{}
+
+	_, _ = w.WriteBits(Bits{T, F, T})
+
+	// AllocsPerRun makes a warm-up call as well
+	w.Grow(2 * len(data) * bitsPerByte)
+
+	allocs := testing.AllocsPerRun(1, func() {
+		_, _ = w.WriteBytes(data)
+	})
+
+	if allocs != 0 {
+		t.Errorf("%v allocations after Grow, want 0", allocs)
+	}
+
+	if want := int64(3 + 2*len(data)*bitsPerByte); w.BitLen() != want {
+		t.Errorf("BitLen() = %v, want %v", w.BitLen(), want)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected Grow() to panic for a negative count")
+		}
+	}()
+
+	w.Grow(-1)
+}
+
+func TestWriter_Pool(t *testing.T) {
+	const packetBits = 1500 * bitsPerByte
+
+	pool := sync.Pool{New: func() interface{} { return NewWriter(packetBits) }}
+	packet := make([]byte, packetBits/bitsPerByte)
+
+	encode := func() {
+		w := pool.Get().(*Writer)
+
+		_, _ = w.WriteUint(7, 3)
+		_, _ = w.WriteBytes(packet[1:])
+
+		w.Reset()
+		pool.Put(w)
+	}
+
+	encode()
+
+	// the pool may drop a Writer at any time, so only check that reusing one does not reallocate
+	w := pool.Get().(*Writer)
+	pool.Put(w)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		_, _ = w.WriteUint(7, 3)
+		_, _ = w.WriteBytes(packet[1:])
+		w.Reset()
+	})
+
+	if allocs != 0 {
+		t.Errorf("%v allocations per packet, want 0", allocs)
+	}
+}
+
+type version uint8
+
+type header struct {
+	magic   string
+	version version
+	size    *Placeholder
+}
+
+func (h *header) MarshalBits(w *Writer) (err error) {
+	if _, err = w.Write(h.magic, h.version); err != nil {
+		return err
+	}
+
+	h.size, err = w.Reserve(12)
+
+	return err
+}
+
+type badMarshaler struct{}
+
+func (badMarshaler) MarshalBits(w *Writer) error {
+	_, err := w.WriteBit(T)
+	if err != nil {
+		return err
+	}
+
+	return io.ErrUnexpectedEOF
+}
+
+func TestWriter_WriteKinds(t *testing.T) {
+	tests := []struct {
+		name            string
+		arg             interface{}
+		wantBitsWritten int
+		expectedBytes   []byte
+	}{
+		{"uint8", uint8(0xAB), 8, []byte{0xAB}},
+		{"uint16", uint16(0xABCD), 16, []byte{0xCD, 0xAB}},
+		{"uint32", uint32(0x01020304), 32, []byte{4, 3, 2, 1}},
+		{"uint64", uint64(1), 64, []byte{1, 0, 0, 0, 0, 0, 0, 0}},
+		{"uint", uint(1), 64, []byte{1, 0, 0, 0, 0, 0, 0, 0}},
+		{"int8", int8(-1), 8, []byte{0xFF}},
+		{"int16", int16(-2), 16, []byte{0xFE, 0xFF}},
+		{"int32", int32(-2), 32, []byte{0xFE, 0xFF, 0xFF, 0xFF}},
+		{"int", -1, 64, []byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}},
+		{"float32", float32(1), 32, []byte{0, 0, 0x80, 0x3F}},
+		{"float64", 1.0, 64, []byte{0, 0, 0, 0, 0, 0, 0xF0, 0x3F}},
+		{"named integer", version(3), 8, []byte{3}},
+		{"[]bool", []bool{T, F, T}, 3, []byte{0b101}},
+		{"[]uint16", []uint16{1, 2}, 32, []byte{1, 0, 2, 0}},
+		{"[]string", []string{"a", "bc"}, 24, []byte{'a', 'b', 'c'}},
+		{"array", [2]int8{-1, 1}, 16, []byte{0xFF, 1}},
+		{"[]interface{}", []interface{}{T, uint8(1)}, 9, []byte{0b11, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &Writer{}
+
+			gotBitsWritten, err := w.Write(tt.arg)
+			if err != nil {
+				t.Errorf("Write() error = %v", err)
+				return
+			}
+
+			if gotBitsWritten != tt.wantBitsWritten {
+				t.Errorf("Write() gotBitsWritten = %v, want %v", gotBitsWritten, tt.wantBitsWritten)
+			}
+
+			if got := w.Bytes(); !reflect.DeepEqual(got, tt.expectedBytes) {
+				t.Errorf("Bytes() = %v, want %v", got, tt.expectedBytes)
+			}
+		})
+	}
+}
+
+func TestWriter_WriteMarshaler(t *testing.T) {
+	w := &Writer{}
+	h := &header{magic: "D2", version: 7}
+
+	bitsWritten, err := w.Write(T, h, []byte{0xFF})
+	if err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	if bitsWritten != 1+16+8+12+8 {
+		t.Errorf("Write() gotBitsWritten = %v, want %v", bitsWritten, 1+16+8+12+8)
+	}
+
+	_ = h.size.FillUint(uint64(w.BitLen()))
+
+	r := NewReader().FromBytes(w.Bytes()...)
+	_ = r.Next(1).Bits()
+
+	if got, _ := r.Next(2).Bytes().AsBytes(); string(got) != "D2" {
+		t.Errorf("magic = %q, want %q", got, "D2")
+	}
+
+	if got, _ := r.Next(8).Bits().AsUInt8(); got != 7 {
+		t.Errorf("version = %v, want 7", got)
+	}
+
+	if got, _ := r.Next(12).Bits().AsUInt(); got != 45 {
+		t.Errorf("size = %v, want 45", got)
+	}
+
+	bitsWritten, err = w.Write(F, badMarshaler{}, T)
+
+	var argErr *ArgumentError
+	if !errors.As(err, &argErr) || argErr.Index != 1 || !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("Write() error = %v, want an *ArgumentError at index 1", err)
+	}
+
+	if bitsWritten != 2 {
+		t.Errorf("Write() gotBitsWritten = %v, want 2", bitsWritten)
+	}
+
+	// errors from the write methods propagate too
+	_ = w.Close()
+
+	if _, err := w.Write(uint8(1)); !errors.Is(err, ErrClosed) {
+		t.Errorf("Write() error = %v, want %v", err, ErrClosed)
+	}
+
+	_, err = (&Writer{}).Write([]interface{}{T, nil})
+	if !errors.As(err, &argErr) || argErr.Index != 0 || !errors.Is(err, ErrUnsupportedType) {
+		t.Errorf("Write() error = %v, want an *ArgumentError wrapping %v", err, ErrUnsupportedType)
+	}
+}
+
+func TestWriter_Reader(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	for _, bigEndian := range []bool{false, true} {
+		for numBits := 0; numBits <= 70; numBits++ {
+			w := &Writer{}
+			if bigEndian {
+				w.SetBigEndian()
+			}
+
+			written := make(Bits, numBits)
+			for idx := range written {
+				written[idx] = rng.Intn(2) == 1
+			}
+
+			_, _ = w.WriteBits(written)
+
+			r := w.Reader()
+
+			if got := r.Next(numBits).Bits(); got.Error != nil || !reflect.DeepEqual(got.Bits, written) {
+				t.Errorf("%v bits, big endian %v: Bits() = %v, %v, want %v", numBits, bigEndian, got.Bits, got.Error, written)
+			}
+
+			if _, err := r.Next(1).Bits().AsBool(); !errors.Is(err, io.EOF) {
+				t.Errorf("%v bits, big endian %v: reading past the end error = %v, want %v", numBits, bigEndian, err, io.EOF)
+			}
+
+			if got := w.Bits(); !reflect.DeepEqual(got, written) && !(len(got) == 0 && numBits == 0) {
+				t.Errorf("%v bits, big endian %v: Bits() = %v, want %v", numBits, bigEndian, got, written)
+			}
+		}
+	}
+}
+
+func TestWriter_ReaderSharesBuffer(t *testing.T) {
+	w := NewWriter(1024)
+
+	_, _ = w.WriteBytes(make([]byte, 100))
+	_, _ = w.WriteBits(Bits{T, T, T})
+
+	r := w.Reader()
+
+	if &r.buf[0] != &w.bytes[0] {
+		t.Errorf("expected the Reader to share the Writer's buffer")
+	}
+
+	// writing more bits does not change the bits the Reader covers
+	_, _ = w.WriteBytes(make([]byte, 100))
+
+	_ = r.Next(100).Bytes()
+
+	if got := r.Next(3).Bits(); got.Error != nil || !reflect.DeepEqual(got.Bits, Bits{T, T, T}) {
+		t.Errorf("Bits() = %v, %v, want %v", got.Bits, got.Error, Bits{T, T, T})
+	}
+
+	if got := r.Next(1).Bits(); !errors.Is(got.Error, io.EOF) {
+		t.Errorf("reading past the end error = %v, want %v", got.Error, io.EOF)
+	}
+}
+
+func TestWriter_ReaderStreaming(t *testing.T) {
+	w := NewWriterTo(ioutil.Discard)
+
+	_, _ = w.WriteBytes([]byte{1, 2, 3})
+	_ = w.Flush(false)
+	_, _ = w.WriteUint(0x1F5, 9)
+
+	if got, err := w.Reader().Next(9).Bits().AsUInt(); got != 0x1F5 || err != nil {
+		t.Errorf("AsUInt() = %v, %v, want %v", got, err, 0x1F5)
+	}
+}
+
+func BenchmarkWriter_Sequential(b *testing.B) {
+	const numBytes = 64 * 1024
+
+	data := make([]byte, numBytes)
+	rand.New(rand.NewSource(1)).Read(data)
+
+	bits := make(Bits, 13)
+	for idx := range bits {
+		bits[idx] = idx%3 == 0
+	}
+
+	benchmarks := []struct {
+		name  string
+		write func(w *Writer)
+	}{
+		{"WriteBit", func(w *Writer) {
+			for idx := 0; idx < numBytes*bitsPerByte; idx++ {
+				_, _ = w.WriteBit(data[idx/bitsPerByte]&1 == 1)
+			}
+		}},
+		{"WriteByte", func(w *Writer) {
+			for idx := range data {
+				_, _ = w.WriteByte(data[idx])
+			}
+		}},
+		{"WriteBytes", func(w *Writer) {
+			_, _ = w.WriteBytes(data)
+		}},
+		{"WriteBits 13bit", func(w *Writer) {
+			for idx := 0; idx < numBytes*bitsPerByte/len(bits); idx++ {
+				_, _ = w.WriteBits(bits)
+			}
+		}},
+		{"WriteUint 13bit", func(w *Writer) {
+			for idx := 0; idx < numBytes*bitsPerByte/13; idx++ {
+				_, _ = w.WriteUint(uint64(data[idx%numBytes])<<5, 13)
+			}
+		}},
+	}
+
+	for _, bm := range benchmarks {
+		bm := bm
+
+		b.Run(bm.name, func(b *testing.B) {
+			b.SetBytes(numBytes)
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				w := NewWriterTo(ioutil.Discard)
+
+				bm.write(w)
+
+				_ = w.Close()
+			}
+		})
+	}
+}